@@ -0,0 +1,69 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+const (
+	barFull  = "█"
+	barEmpty = "░"
+)
+
+// Sparkline Create a mini bar chart from a series of values
+// Each value is mapped to one block character, scaled between the
+// smallest and the largest value of the series
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		idx := len(sparks) / 2
+		switch {
+		case math.IsNaN(v):
+			sb.WriteString(SPACE)
+			continue
+		case max > min:
+			idx = int((v - min) / (max - min) * float64(len(sparks)-1))
+		}
+		sb.WriteRune(sparks[idx])
+	}
+	return sb.String()
+}
+
+// ProgressBar Create a progress bar followed by its percentage
+// fraction is clamped to [0,1] and the bar is width characters wide
+func ProgressBar(fraction float64, width int) string {
+	if math.IsNaN(fraction) || fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+	if width < 0 {
+		width = 0
+	}
+	filled := int(math.Round(fraction * float64(width)))
+	return fmt.Sprintf("[%s%s] %d%%",
+		strings.Repeat(barFull, filled),
+		strings.Repeat(barEmpty, width-filled),
+		int(math.Round(fraction*100)))
+}
@@ -11,6 +11,8 @@ import (
 	"encoding/csv"
 	"io"
 	"os"
+	"strings"
+	"unicode/utf8"
 )
 
 // NewCSV Start A new table by importing from a CSV file
@@ -26,27 +28,182 @@ func NewCSV(writer io.Writer, fileName string, hasHeader bool) (*Table, error) {
 	return t, err
 }
 
+// FromCSVFile Start A new table by importing from a CSV file
+// Takes io.Writer, csv File name and the field delimiter
+func FromCSVFile(writer io.Writer, fileName string, hasHeader bool, delimiter rune) (*Table, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return &Table{}, err
+	}
+	defer file.Close()
+	csvReader := csv.NewReader(file)
+	csvReader.Comma = delimiter
+	return NewCSVReader(writer, csvReader, hasHeader)
+}
+
+// CSVOptions Options of NewCSVReaderWithOptions
+type CSVOptions struct {
+	// InferAlignment aligns to the right the columns where every value is
+	// a number and leaves the alignment of the other ones to SetAlignment
+	// and the detection of numbers. The header is not scanned.
+	InferAlignment bool
+	// SkipEmpty skips the records where every field is blank.
+	SkipEmpty bool
+	// CommentPrefix skips the lines starting with it. A single character
+	// prefix is handled by csv.Reader.Comment, longer ones are matched on the
+	// first field of each record, which may need FieldsPerRecord = -1.
+	CommentPrefix string
+}
+
 // NewCSVReader Start a New Table Writer with csv.Reader
 // This enables customisation such as reader.Comma = ';'
 // See http://golang.org/src/pkg/encoding/csv/reader.go?s=3213:3671#L94
 func NewCSVReader(writer io.Writer, csvReader *csv.Reader, hasHeader bool) (*Table, error) {
+	return NewCSVReaderWithOptions(writer, csvReader, hasHeader, CSVOptions{})
+}
+
+// NewCSVReaderWithOptions Start a New Table Writer with csv.Reader and options
+func NewCSVReaderWithOptions(writer io.Writer, csvReader *csv.Reader, hasHeader bool, opts CSVOptions) (*Table, error) {
+	// The Comment of the reader is only changed while reading
+	if utf8.RuneCountInString(opts.CommentPrefix) == 1 {
+		defer func(comment rune) { csvReader.Comment = comment }(csvReader.Comment)
+		csvReader.Comment, _ = utf8.DecodeRuneInString(opts.CommentPrefix)
+	}
+	read := func() ([]string, error) {
+		for {
+			record, err := csvReader.Read()
+			if err != nil {
+				return nil, err
+			}
+			if opts.CommentPrefix != "" && strings.HasPrefix(record[0], opts.CommentPrefix) {
+				continue
+			}
+			if opts.SkipEmpty && isEmptyRecord(record) {
+				continue
+			}
+			return record, nil
+		}
+	}
+
 	t := NewWriter(writer)
 	if hasHeader {
 		// Read the first row
-		headers, err := csvReader.Read()
+		headers, err := read()
 		if err != nil {
 			return &Table{}, err
 		}
 		t.SetHeader(headers)
 	}
+	var records [][]string
 	for {
-		record, err := csvReader.Read()
+		record, err := read()
 		if err == io.EOF {
 			break
 		} else if err != nil {
 			return &Table{}, err
 		}
-		t.Append(record)
+		records = append(records, record)
+	}
+	if opts.InferAlignment {
+		t.SetColumnAlignment(inferAlignment(records))
 	}
+	t.AppendBulk(records)
 	return t, nil
 }
+
+// CSVWriteOptions Options of RenderCSV
+type CSVWriteOptions struct {
+	// Comma is the field delimiter, ',' when zero.
+	Comma rune
+	// BOM writes the UTF-8 byte order mark first, which Excel needs to
+	// detect UTF-8 and read non-ASCII text correctly.
+	BOM bool
+}
+
+// utf8BOM - the UTF-8 byte order mark
+const utf8BOM = "\ufeff"
+
+// RenderCSV Write the header, the rows and the footer of the table as CSV
+// The cells are written as they were given to the table, before wrapping
+// and formatting, the newlines they hold being quoted.
+func (t *Table) RenderCSV(opts CSVWriteOptions) error {
+	if opts.BOM {
+		if _, err := io.WriteString(t.out, utf8BOM); err != nil {
+			return err
+		}
+	}
+	w := csv.NewWriter(t.out)
+	if opts.Comma != 0 {
+		w.Comma = opts.Comma
+	}
+	if len(t.headers) > 0 {
+		if err := w.Write(csvRecord(t.headers, t.rawHeaders)); err != nil {
+			return err
+		}
+	}
+	for i, row := range t.lines {
+		if err := w.Write(csvRecord(row, t.rawLines[i])); err != nil {
+			return err
+		}
+	}
+	if len(t.footers) > 0 {
+		if err := w.Write(csvRecord(t.footers, t.rawFooters)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// csvRecord - the source value of each cell of a row
+// The cells without one get their lines joined with spaces.
+func csvRecord(cells [][]string, raw []string) []string {
+	record := make([]string, len(cells))
+	for i, lines := range cells {
+		if i < len(raw) {
+			record[i] = raw[i]
+		} else {
+			record[i] = cellText(lines)
+		}
+	}
+	return record
+}
+
+// isEmptyRecord - check if every field of a record is blank
+func isEmptyRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// inferAlignment - right for the columns holding only numbers, default otherwise
+func inferAlignment(records [][]string) []int {
+	var aligns []int
+	var numbers []int
+	for _, record := range records {
+		for i, v := range record {
+			if i >= len(aligns) {
+				aligns = append(aligns, ALIGN_RIGHT)
+				numbers = append(numbers, 0)
+			}
+			v = strings.TrimSpace(v)
+			switch {
+			case v == "":
+				// empty cells do not tell anything
+			case decimal.MatchString(v) || percent.MatchString(v):
+				numbers[i]++
+			default:
+				aligns[i] = ALIGN_DEFAULT
+			}
+		}
+	}
+	for i := range aligns {
+		if numbers[i] == 0 {
+			aligns[i] = ALIGN_DEFAULT
+		}
+	}
+	return aligns
+}
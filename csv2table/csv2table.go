@@ -18,6 +18,8 @@ var (
 	align     = flag.String("a", "none", "Set alignment with eg. none|left|right|center")
 	pipe      = flag.Bool("p", false, "Support for Piping from STDIN")
 	border    = flag.Bool("b", true, "Enable / disable table border")
+	comment   = flag.String("c", "", "Skip lines starting with comment prefix eg. #")
+	skipEmpty = flag.Bool("e", false, "Skip records with only empty fields")
 )
 
 // main go function
@@ -67,20 +69,20 @@ func process(r io.Reader) {
 	}
 	csvReader.Comma = rune
 
-	table, err := tablewriter.NewCSVReader(os.Stdout, csvReader, *header)
+	table, err := tablewriter.NewCSVReaderWithOptions(os.Stdout, csvReader, *header, tablewriter.CSVOptions{
+		SkipEmpty:     *skipEmpty,
+		CommentPrefix: *comment,
+	})
 
 	if err != nil {
 		exit(err)
 	}
 
-	switch *align {
-	case "left":
-		table.SetAlignment(tablewriter.ALIGN_LEFT)
-	case "right":
-		table.SetAlignment(tablewriter.ALIGN_RIGHT)
-	case "center":
-		table.SetAlignment(tablewriter.ALIGN_CENTER)
+	alignment, err := tablewriter.ParseAlignment(*align)
+	if err != nil {
+		exit(err)
 	}
+	table.SetAlignment(alignment)
 	table.SetBorder(*border)
 	table.Render()
 }
@@ -0,0 +1,189 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "strings"
+
+// TableLayout Computed geometry of a table
+type TableLayout struct {
+	// ColumnWidths is the content width of each column, padding excluded
+	ColumnWidths []int
+	// RowHeights is the number of lines of each row
+	RowHeights []int
+	// HeaderHeight and FooterHeight are the number of lines of the header
+	// and the footer, 0 when not set
+	HeaderHeight int
+	FooterHeight int
+	// Width and Height are the dimensions of the rendered table
+	Width  int
+	Height int
+}
+
+// Layout Get the geometry of the table without rendering it
+func (t *Table) Layout() TableLayout {
+	defer t.keepLayout()()
+	t.fillWidths()
+	l := TableLayout{
+		ColumnWidths: make([]int, len(t.cs)),
+		RowHeights:   make([]int, len(t.lines)),
+		Width:        t.getTableWidth(),
+		Height:       t.renderedHeight(),
+	}
+	for i := range l.ColumnWidths {
+		l.ColumnWidths[i] = t.cs[i]
+	}
+	for i := range l.RowHeights {
+		l.RowHeights[i] = t.rs[i]
+	}
+	if len(t.headers) > 0 {
+		l.HeaderHeight = t.rs[headerRowIdx]
+	}
+	if len(t.footers) > 0 {
+		l.FooterHeight = t.rs[footerRowIdx]
+	}
+	return l
+}
+
+// ColumnAlignments Get the alignment each column renders with
+// Columns left to the default alignment are ALIGN_RIGHT when every
+// non-empty cell is detected as a number and ALIGN_LEFT otherwise
+func (t *Table) ColumnAlignments() []int {
+	defer t.keepLayout()()
+	t.fillWidths()
+	return t.columnAlignments()
+}
+
+// columnAlignments - alignment of each column with the widths already filled
+func (t *Table) columnAlignments() []int {
+	aligns := make([]int, len(t.cs))
+	for col := range aligns {
+		aligns[col] = t.cellAlignment(col, "")
+		right := 0
+	rows:
+		for _, row := range t.lines {
+			if col >= len(row) {
+				continue
+			}
+			for _, line := range row[col] {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				if align := t.cellAlignment(col, line); align != ALIGN_RIGHT {
+					aligns[col] = align
+					right = 0
+					break rows
+				}
+				right++
+			}
+		}
+		if right > 0 {
+			aligns[col] = ALIGN_RIGHT
+		}
+	}
+	return aligns
+}
+
+// VisitCells Call fn for each line of each cell, as laid out by Render
+// Nothing is written to the output. row is the row index, -1 for the
+// header and -2 for the footer. lineIdx is the line within the row, text
+// the content of the line without padding, empty for the lines added to
+// fill the row height. width is the column width and align the resolved
+// horizontal alignment.
+func (t *Table) VisitCells(fn func(row, col, lineIdx int, text string, width, align int)) {
+	defer t.keepLayout()()
+	t.fillWidths()
+	if len(t.headers) > 0 {
+		aligns := t.headerAlignments()
+		for col := 0; col < len(t.cs); col++ {
+			align := aligns[col]
+			for x := 0; x < t.rs[headerRowIdx]; x++ {
+				text := cellLine(t.headers, col, x)
+				if t.autoFmt && text != "" {
+					text = strings.TrimSpace(t.headerText(text))
+				}
+				fn(headerRowIdx, col, x, text, t.cs[col], centerDefault(align))
+			}
+		}
+	}
+	for i, row := range t.lines {
+		for col := 0; col < len(t.cs); col++ {
+			var lines []string
+			if col < len(row) {
+				lines = row[col]
+			}
+			for x, line := range t.padHeight(col, lines, t.rs[i]) {
+				line = strings.TrimSpace(line)
+				fn(i, col, x, line, t.cs[col], t.cellAlignment(col, line))
+			}
+		}
+	}
+	if len(t.footers) > 0 {
+		for col := 0; col < len(t.cs); col++ {
+			align := t.fAlign
+			if col < len(t.footerColumnsAlign) && t.footerColumnsAlign[col] != ALIGN_DEFAULT {
+				align = t.footerColumnsAlign[col]
+			}
+			for x := 0; x < t.rs[footerRowIdx]; x++ {
+				text := cellLine(t.footers, col, x)
+				if t.autoFmt && text != "" {
+					text = strings.TrimSpace(t.titleFunc(text))
+				}
+				fn(footerRowIdx, col, x, text, t.cs[col], centerDefault(align))
+			}
+		}
+	}
+}
+
+// RenderMatrix Get the lines of the rows as Render lays them out
+// Each line is a slice of cells padded to the column width and aligned, with
+// no border, separator nor cell spacing. A row gives as many lines as its
+// height. The header and the footer are not included. It is nil when the
+// table exceeds SetMaxTableWidthStrict.
+func (t *Table) RenderMatrix() [][]string {
+	defer t.keepLayout()()
+	t.fillWidths()
+	if t.checkMaxWidth() != nil {
+		return nil
+	}
+	var matrix [][]string
+	for i, row := range t.lines {
+		cells := make([][]string, len(t.cs))
+		for col := range cells {
+			var lines []string
+			if col < len(row) {
+				lines = row[col]
+			}
+			cells[col] = t.padHeight(col, lines, t.rs[i])
+		}
+		for x := 0; x < t.rs[i]; x++ {
+			line := make([]string, len(t.cs))
+			for col := range line {
+				text := cellLine(cells, col, x)
+				line[col] = t.pad(t.cellAlignment(col, text))(text, SPACE, t.cs[col])
+			}
+			matrix = append(matrix, line)
+		}
+	}
+	return matrix
+}
+
+// cellLine - line x of the cell at col, empty when there is none
+func cellLine(cells [][]string, col, x int) string {
+	if col < len(cells) && x < len(cells[col]) {
+		return strings.TrimSpace(cells[col][x])
+	}
+	return ""
+}
+
+// centerDefault - header and footer cells are centered by default
+func centerDefault(align int) int {
+	if align == ALIGN_DEFAULT {
+		return ALIGN_CENTER
+	}
+	return align
+}
@@ -9,13 +9,17 @@
 package tablewriter
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 const (
@@ -37,9 +41,61 @@ const (
 	ALIGN_LEFT
 )
 
+// ColumnType tells how the default alignment of a column is chosen
+type ColumnType int
+
+const (
+	TYPE_AUTO ColumnType = iota
+	TYPE_TEXT
+	TYPE_NUMBER
+)
+
+const (
+	VALIGN_TOP = iota
+	VALIGN_MIDDLE
+	VALIGN_BOTTOM
+)
+
+const (
+	LINE_SOLID = iota
+	LINE_DASHED
+	LINE_DOTTED
+)
+
+const (
+	DOT      = "·"
+	ELLIPSIS = "…"
+)
+
+const (
+	WRAP_DEFAULT = iota
+	WRAP_BALANCED
+)
+
+const (
+	POSITION_BOTTOM = iota
+	POSITION_TOP
+)
+
+const (
+	CASE_TITLE = iota
+	CASE_UPPER
+	CASE_LOWER
+	CASE_NONE
+)
+
+const (
+	NEGATIVE_RED = 1 << iota
+	NEGATIVE_PARENS
+	NEGATIVE_BOTH = NEGATIVE_RED | NEGATIVE_PARENS
+)
+
 var (
-	decimal = regexp.MustCompile(`^-?(?:\d{1,3}(?:,\d{3})*|\d+)(?:\.\d+)?$`)
-	percent = regexp.MustCompile(`^-?\d+\.?\d*$%$`)
+	decimal   = regexp.MustCompile(`^-?(?:\d{1,3}(?:,\d{3})*|\d+)(?:\.\d+)?$`)
+	percent   = regexp.MustCompile(`^-?\d+\.?\d*$%$`)
+	humanized = regexp.MustCompile(`^-?\d+(?:\.\d+)?[KMBT]$`)
+	byteSize  = regexp.MustCompile(`^-?\d+(?:\.\d+)? (?:B|[KMGTPE]i?B)$`)
+	duration  = regexp.MustCompile(`^-?(?:\d+(?:\.\d+)?(?:ns|µs|ms|s|m|h|d))+$`)
 )
 
 type Border struct {
@@ -78,6 +134,8 @@ type Table struct {
 	footers                 [][]string
 	caption                 bool
 	captionText             string
+	captionBoxed            bool
+	captionPosition         int
 	autoFmt                 bool
 	autoWrap                bool
 	reflowText              bool
@@ -104,65 +162,494 @@ type Table struct {
 	columnsParams           []string
 	footerParams            []string
 	columnsAlign            []int
+	headerColumnsAlign      []int
+	footerColumnsAlign      []int
+	columnsDecimals         map[int]int
+	titleFunc               func(string) string
+	rowLineStyle            int
+	minWidth                int
+	footerPosition          int
+	wrapMode                int
+	headerKeys              []string
+	columnsType             map[int]ColumnType
+	headerBgParams          string
+	headerCs                map[int]int
+	columnsMinWidth         map[int]int
+	columnsMaxWidth         map[int]int
+	autoTruncate            bool
+	truncateIndicator       string
+	emptyPlaceholder        string
+	columnsVAlign           []int
+	grid                    bool
+	maxRowHeight            int
+	indent                  int
+	columnSeps              map[int]string
+	columnHeavy             map[int]bool
+	cellSpacing             int
+	negativeStyle           int
+	columnsUnitSplit        map[int]bool
+	escapeSeparator         bool
+	trimTrailingSpace       bool
+	deterministic           bool
+	dropEmptyColumns        bool
+	maxRows                 int
+	headerCase              int
+	columnLine              bool
+	decimalSep              rune
+	autoMergeHorizontal     bool
+	maxWidthStrict          int
+	wrapFunc                func(string, int) []string
+	widthFunc               func(string) int
+	selection               map[int]bool
+	selectionGlyphs         [2]string
+	collapseBlank           bool
+	columnsHumanize         map[int]bool
+	humanizePrecision       int
+	columnsBytes            map[int]bool
+	humanizeBytesSI         bool
+	columnsDuration         map[int]bool
+	nilText                 string
+	timeLayout              string
+	timeLocation            *time.Location
+	elide                   bool
+	rowRendered             func(rowIdx int)
+	gutters                 map[int]bool
+	tableAlign              int
+	alignedPadding          bool
+	lastFrame               []string
+	footerSeparator         bool
+	emptyMessage            string
+	legend                  []string
+	sections                map[int]string
+	rowParams               map[int][]string
+	rawHeaders              []string
+	rawFooters              []string
+	rawLines                [][]string
+	keepURLs                bool
+	forceColor              bool
+	headerFollowAlign       bool
+	cellTransform           func(row, col int, value string) string
+	pageWidth               int
+	err                     error
+	settingErr              error
 }
 
 // NewWriter Start New Table
 // Take io.Writer Directly
 func NewWriter(writer io.Writer) *Table {
 	t := &Table{
-		out:           writer,
-		rows:          [][]string{},
-		lines:         [][][]string{},
-		cs:            make(map[int]int),
-		rs:            make(map[int]int),
-		headers:       [][]string{},
-		footers:       [][]string{},
-		caption:       false,
-		captionText:   "Table caption.",
-		autoFmt:       true,
-		autoWrap:      true,
-		reflowText:    true,
-		mW:            MAX_ROW_WIDTH,
-		syms:          simpleSyms(CENTER, ROW, COLUMN),
-		pCenter:       CENTER,
-		pRow:          ROW,
-		pColumn:       COLUMN,
-		tColumn:       -1,
-		tRow:          -1,
-		hAlign:        ALIGN_DEFAULT,
-		fAlign:        ALIGN_DEFAULT,
-		align:         ALIGN_DEFAULT,
-		newLine:       NEWLINE,
-		rowLine:       false,
-		hdrLine:       true,
-		borders:       Border{Left: true, Right: true, Bottom: true, Top: true},
-		colSize:       -1,
-		headerParams:  []string{},
-		columnsParams: []string{},
-		footerParams:  []string{},
-		columnsAlign:  []int{}}
+		out:                writer,
+		rows:               [][]string{},
+		lines:              [][][]string{},
+		cs:                 make(map[int]int),
+		rs:                 make(map[int]int),
+		headers:            [][]string{},
+		footers:            [][]string{},
+		caption:            false,
+		captionText:        "Table caption.",
+		autoFmt:            true,
+		autoWrap:           true,
+		reflowText:         true,
+		mW:                 MAX_ROW_WIDTH,
+		syms:               simpleSyms(CENTER, ROW, COLUMN),
+		pCenter:            CENTER,
+		pRow:               ROW,
+		pColumn:            COLUMN,
+		tColumn:            -1,
+		tRow:               -1,
+		hAlign:             ALIGN_DEFAULT,
+		fAlign:             ALIGN_DEFAULT,
+		align:              ALIGN_DEFAULT,
+		newLine:            NEWLINE,
+		rowLine:            false,
+		hdrLine:            true,
+		borders:            Border{Left: true, Right: true, Bottom: true, Top: true},
+		colSize:            -1,
+		headerParams:       []string{},
+		columnsParams:      []string{},
+		footerParams:       []string{},
+		columnsAlign:       []int{},
+		headerColumnsAlign: []int{},
+		footerColumnsAlign: []int{},
+		columnsDecimals:    make(map[int]int),
+		columnsType:        make(map[int]ColumnType),
+		headerCs:           make(map[int]int),
+		columnsMinWidth:    make(map[int]int),
+		columnsMaxWidth:    make(map[int]int),
+		columnSeps:         make(map[int]string),
+		columnHeavy:        make(map[int]bool),
+		cellSpacing:        1,
+		columnLine:         true,
+		decimalSep:         '.',
+		columnsUnitSplit:   make(map[int]bool),
+		columnsHumanize:    make(map[int]bool),
+		humanizePrecision:  1,
+		nilText:            "nil",
+		footerSeparator:    true,
+		columnsBytes:       make(map[int]bool),
+		columnsDuration:    make(map[int]bool),
+		truncateIndicator:  ELLIPSIS,
+		keepURLs:           true,
+		titleFunc:          Title,
+		rowLineStyle:       LINE_SOLID}
 	return t
 }
 
+// SetIndent Set the number of spaces printed before every line of the table
+func (t *Table) SetIndent(n int) {
+	t.indent = n
+}
+
+// SetAlignedPadding Print right aligned body cells against their right separator
+// The space between the cell and the separator moves to the left of the
+// cell, so the numbers hug the border while the text keeps its padding.
+func (t *Table) SetAlignedPadding(aligned bool) {
+	t.alignedPadding = aligned
+}
+
+// SetTableAlignment Set the position of the whole table within a page width
+// With ALIGN_CENTER or ALIGN_RIGHT every line is shifted so the table is
+// centered or right justified within pageWidth, after the SetIndent spaces.
+// A table wider than the page stays on the left.
+func (t *Table) SetTableAlignment(align int, pageWidth int) {
+	t.tableAlign = align
+	t.pageWidth = pageWidth
+}
+
+// SetOutput Set the writer the table is rendered to
+func (t *Table) SetOutput(writer io.Writer) {
+	t.out = writer
+}
+
+// RenderTo Render the table to w, leaving the writer of the table unchanged
+func (t *Table) RenderTo(w io.Writer) {
+	out := t.out
+	t.out = w
+	defer func() { t.out = out }()
+	t.Render()
+}
+
+// RenderToErr Render the table to w like RenderTo and return the error
+// It is the first error of w, or else the error of Err.
+func (t *Table) RenderToErr(w io.Writer) error {
+	ew := &errWriter{w: w}
+	t.RenderTo(ew)
+	if ew.err != nil {
+		return ew.err
+	}
+	return t.Err()
+}
+
+// RenderUpdate Render the table again over the previous RenderUpdate
+// On a terminal only the lines that changed since the previous call are
+// written, moving the cursor with ANSI escape sequences, so a table updated
+// with UpdateRow is refreshed in place without flicker. Otherwise, and on
+// the first call, the whole table is rendered.
+func (t *Table) RenderUpdate() {
+	var buf bytes.Buffer
+	t.RenderTo(&buf)
+	frame := strings.SplitAfter(buf.String(), NEWLINE)
+	if len(frame) > 0 && frame[len(frame)-1] == "" {
+		frame = frame[:len(frame)-1]
+	}
+	if t.lastFrame == nil || !isTerminal(t.out) {
+		buf.WriteTo(t.out)
+		t.lastFrame = frame
+		return
+	}
+
+	var out bytes.Buffer
+	if len(t.lastFrame) > 0 {
+		fmt.Fprintf(&out, "\033[%dA", len(t.lastFrame))
+	}
+	down := 0
+	for i, line := range frame {
+		if i < len(t.lastFrame) && line == t.lastFrame[i] {
+			down++
+			continue
+		}
+		if down > 0 {
+			fmt.Fprintf(&out, "\033[%dB", down)
+			down = 0
+		}
+		fmt.Fprint(&out, "\r", strings.TrimRight(line, "\r\n"), "\033[K", t.newLine)
+	}
+	if down > 0 {
+		fmt.Fprintf(&out, "\033[%dB", down)
+	}
+	if len(frame) < len(t.lastFrame) {
+		// Clear the lines left by a taller previous table
+		fmt.Fprint(&out, "\r\033[J")
+	}
+	out.WriteTo(t.out)
+	t.lastFrame = frame
+}
+
 // Render table output
 func (t *Table) Render() {
+	defer t.deterministicOutput()()
+	defer t.bufferOutput()()
+	defer t.indentOutput()()
+	defer t.trimOutput()()
+	defer t.keepLayout()()
+	t.fillWidths()
+	if t.checkMaxWidth() != nil {
+		return
+	}
+	if t.caption && t.captionPosition == POSITION_TOP {
+		t.printCaption()
+	}
 	if t.borders.Top {
-		t.printLine(true, false)
+		t.printTopLine()
+	}
+	t.printHeaderCells()
+	if !t.headerLineDropped() {
+		t.printHeaderLine()
+	}
+	if t.footerPosition == POSITION_TOP {
+		t.printTopFooter()
 	}
-	t.printHeading()
 	if t.autoMergeCells {
 		t.printRowsMergeCells()
 	} else {
 		t.printRows()
 	}
-	if !t.rowLine && t.borders.Bottom {
-		t.printLine(false, len(t.footers) == 0)
+	if !t.rowLine && t.hasBottomLine() && !t.closedByTopFooter() {
+		t.printBottomLine()
+	}
+	if t.footerPosition != POSITION_TOP {
+		t.printFooter()
 	}
-	t.printFooter()
 
-	if t.caption {
+	if t.caption && t.captionPosition != POSITION_TOP {
+		t.printCaption()
+	}
+	t.printLegend()
+}
+
+// RenderHeader Render only the top border and the header
+// With RenderRow and RenderFooter it allows printing the table in pieces.
+// The caption and the footer set at the top are printed with the header.
+func (t *Table) RenderHeader() {
+	defer t.deterministicOutput()()
+	defer t.bufferOutput()()
+	defer t.indentOutput()()
+	defer t.trimOutput()()
+	defer t.keepLayout()()
+	t.fillWidths()
+	if t.checkMaxWidth() != nil {
+		return
+	}
+	if t.caption && t.captionPosition == POSITION_TOP {
 		t.printCaption()
 	}
+	if t.borders.Top {
+		t.printTopLine()
+	}
+	t.printHeaderCells()
+	if !t.headerLineDropped() {
+		t.printHeaderLine()
+	}
+	if t.footerPosition == POSITION_TOP {
+		t.printTopFooter()
+	}
+}
+
+// RenderRow Render only the row at index i
+// Nothing is printed if there is no such row, or if it is a blank row
+// removed by SetCollapseBlankRows.
+func (t *Table) RenderRow(i int) {
+	if i < 0 || i >= len(t.lines) {
+		return
+	}
+	// The index of the row once the blank rows before it are collapsed
+	row := i
+	if t.collapseBlank {
+		if isBlankRow(t.lines[i]) {
+			return
+		}
+		for _, cells := range t.lines[:i] {
+			if isBlankRow(cells) {
+				row--
+			}
+		}
+	}
+	defer t.deterministicOutput()()
+	defer t.bufferOutput()()
+	defer t.indentOutput()()
+	defer t.trimOutput()()
+	defer t.keepLayout()()
+	t.fillWidths()
+	if t.checkMaxWidth() != nil {
+		return
+	}
+	t.printRow(t.lines[row], row)
+	t.notifyRowRendered(i)
+}
+
+// notifyRowRendered - call the function of SetRowRenderedCallback
+func (t *Table) notifyRowRendered(rowIdx int) {
+	if t.rowRendered != nil {
+		t.rowRendered(rowIdx)
+	}
+}
+
+// RenderFooter Render only the bottom border, the footer and the caption
+func (t *Table) RenderFooter() {
+	defer t.deterministicOutput()()
+	defer t.bufferOutput()()
+	defer t.indentOutput()()
+	defer t.trimOutput()()
+	defer t.keepLayout()()
+	t.fillWidths()
+	if t.checkMaxWidth() != nil {
+		return
+	}
+	if !t.rowLine && t.hasBottomLine() && !t.closedByTopFooter() {
+		t.printBottomLine()
+	}
+	if t.footerPosition != POSITION_TOP {
+		t.printFooter()
+	}
+	if t.caption && t.captionPosition != POSITION_TOP {
+		t.printCaption()
+	}
+	t.printLegend()
+}
+
+// Err Get the error of the last render, nil if none
+// It is set by Render, RenderHeader, RenderRow, RenderFooter and
+// RenderMatrix. Without one it is the error of the last setting that was
+// not applied in full, like extra column alignments or a minimal column
+// width above the maximal one.
+func (t *Table) Err() error {
+	if t.err != nil {
+		return t.err
+	}
+	return t.settingErr
+}
+
+// checkMaxWidth - check the width against SetMaxTableWidthStrict
+// The error is kept for Err.
+func (t *Table) checkMaxWidth() error {
+	t.err = nil
+	if t.maxWidthStrict <= 0 {
+		return nil
+	}
+	if w := t.getTableWidth() + t.indent; w > t.maxWidthStrict {
+		t.err = fmt.Errorf("table width %d exceeds the maximum width %d", w, t.maxWidthStrict)
+	}
+	return t.err
+}
+
+// fillWidths - apply the column widths computed at render time
+func (t *Table) fillWidths() {
+	t.collapseBlankRows()
+	t.dropColumns()
+	t.addSelectionColumn()
+	t.addGutterColumns()
+	t.fillUnitSplit()
+	t.fillGrid()
+	t.elideColumns()
+	t.fillMinWidth()
+	t.fillHiddenRows()
+}
+
+// keepLayout - let fillWidths change a copy of the rows and widths
+// It returns the function restoring the table as it was before, keeping
+// only the error of the render, so rows appended or updated afterwards
+// are laid out like the first ones.
+func (t *Table) keepLayout() func() {
+	saved := *t
+	t.lines = make([][][]string, len(saved.lines))
+	for i, row := range saved.lines {
+		t.lines[i] = append([][]string(nil), row...)
+	}
+	t.cs = make(map[int]int, len(saved.cs))
+	for col, w := range saved.cs {
+		t.cs[col] = w
+	}
+	t.rs = make(map[int]int, len(saved.rs))
+	for row, h := range saved.rs {
+		t.rs[row] = h
+	}
+	t.columnsAlign = append([]int(nil), saved.columnsAlign...)
+	return func() {
+		err := t.err
+		*t = saved
+		t.err = err
+	}
+}
+
+// bufferOutput - coalesce the writes of Render unless out is already buffered
+// It returns the function flushing the buffer and restoring out
+func (t *Table) bufferOutput() func() {
+	switch t.out.(type) {
+	case *bufio.Writer, *bytes.Buffer, *strings.Builder:
+		return func() {}
+	}
+	if _, ok := t.out.(interface{ Flush() error }); ok {
+		return func() {}
+	}
+	out := t.out
+	w := bufio.NewWriter(out)
+	t.out = w
+	return func() {
+		w.Flush()
+		t.out = out
+	}
+}
+
+// indentOutput - prefix every line written during Render with the indent
+// It returns the function restoring out
+func (t *Table) indentOutput() func() {
+	if t.indent <= 0 && t.tableAlign != ALIGN_CENTER && t.tableAlign != ALIGN_RIGHT {
+		return func() {}
+	}
+	out := t.out
+	// The table width is only known once the columns are filled
+	t.out = &indentWriter{w: out, indent: t.blockIndent, bol: true}
+	return func() {
+		t.out = out
+	}
+}
+
+// blockIndent - spaces before each line for SetIndent and SetTableAlignment
+func (t *Table) blockIndent() int {
+	n := t.indent
+	if gap := t.pageWidth - t.indent - t.getTableWidth(); gap > 0 {
+		switch t.tableAlign {
+		case ALIGN_CENTER:
+			n += gap / 2
+		case ALIGN_RIGHT:
+			n += gap
+		}
+	}
+	return n
+}
+
+// deterministicOutput - apply the settings of SetDeterministic during a render
+func (t *Table) deterministicOutput() func() {
+	if !t.deterministic {
+		return func() {}
+	}
+	nl, trim := t.newLine, t.trimTrailingSpace
+	t.newLine, t.trimTrailingSpace = NEWLINE, true
+	return func() {
+		t.newLine, t.trimTrailingSpace = nl, trim
+	}
+}
+
+// trimOutput - drop the trailing spaces of the lines written to out
+func (t *Table) trimOutput() func() {
+	if !t.trimTrailingSpace {
+		return func() {}
+	}
+	out := t.out
+	t.out = &trimWriter{w: out}
+	return func() {
+		t.out = out
+	}
 }
 
 const (
@@ -177,6 +664,14 @@ func (t *Table) SetHeader(keys []string) {
 		lines := t.parseDimension(v, i, headerRowIdx)
 		t.headers = append(t.headers, lines)
 	}
+	t.rawHeaders = append(t.rawHeaders, keys...)
+}
+
+// SetHeaderFromKeys Set table header from map keys
+// The keys also define the column order used by AppendMap
+func (t *Table) SetHeaderFromKeys(keys []string) {
+	t.headerKeys = append([]string(nil), keys...)
+	t.SetHeader(keys)
 }
 
 // SetFooter Set table Footer
@@ -186,6 +681,7 @@ func (t *Table) SetFooter(keys []string) {
 		lines := t.parseDimension(v, i, footerRowIdx)
 		t.footers = append(t.footers, lines)
 	}
+	t.rawFooters = append(t.rawFooters, keys...)
 }
 
 // SetCaption Set table Caption
@@ -196,29 +692,229 @@ func (t *Table) SetCaption(caption bool, captionText ...string) {
 	}
 }
 
+// SetCaptionBoxed Draw the caption inside its own box
+// The box has the width of the table and uses the table symbols.
+func (t *Table) SetCaptionBoxed(boxed bool) {
+	t.captionBoxed = boxed
+}
+
+// SetCaptionPosition Set the caption above (POSITION_TOP) or below
+// (POSITION_BOTTOM) the table. Default is below.
+func (t *Table) SetCaptionPosition(position int) {
+	t.captionPosition = position
+}
+
+// SetLegend Set the entries of a legend printed under the table
+// such as "✓ passed". It comes below the caption and the entries are wrapped
+// to the table width, an entry being split only when it does not fit alone.
+func (t *Table) SetLegend(items []string) {
+	t.legend = items
+}
+
 // SetAutoFormatHeaders Turn header autoformatting on/off. Default is on (true).
+// When off, headers and footers are printed as provided, aside from wrapping.
 func (t *Table) SetAutoFormatHeaders(auto bool) {
 	t.autoFmt = auto
 }
 
+// SetTitleFunc Set the function used to format headers and footers when
+// autoformatting is on. Default is Title. A nil func restores the default.
+func (t *Table) SetTitleFunc(fn func(string) string) {
+	if fn == nil {
+		fn = Title
+	}
+	t.titleFunc = fn
+}
+
+// SetHeaderCase Set the case of the header when autoformatting is on
+// CASE_TITLE (default) uses the title func, CASE_UPPER and CASE_LOWER only
+// change the case and CASE_NONE prints the header as provided.
+func (t *Table) SetHeaderCase(c int) {
+	t.headerCase = c
+}
+
+// headerText - format a header line according to the header case
+func (t *Table) headerText(h string) string {
+	switch t.headerCase {
+	case CASE_UPPER:
+		return strings.ToUpper(h)
+	case CASE_LOWER:
+		return strings.ToLower(h)
+	case CASE_NONE:
+		return h
+	}
+	return t.titleFunc(h)
+}
+
 // SetAutoWrapText Turn automatic multiline text adjustment on/off. Default is on (true).
 func (t *Table) SetAutoWrapText(auto bool) {
 	t.autoWrap = auto
 }
 
+// SetAutoTruncateText Turn truncation of cells wider than the column width on/off.
+// When on, it replaces wrapping for the rows. Default is off (false).
+func (t *Table) SetAutoTruncateText(auto bool) {
+	t.autoTruncate = auto
+}
+
+// SetTruncateIndicator Set the text ending truncated cells. Default is "…".
+// Its display width is reserved from the column width.
+func (t *Table) SetTruncateIndicator(s string) {
+	t.truncateIndicator = s
+}
+
+// SetMaxRowHeight Set the maximum number of lines of a cell in rows appended afterwards
+// The last line kept is replaced by the truncate indicator, unless it is empty.
+// Default is 0, no limit.
+func (t *Table) SetMaxRowHeight(n int) {
+	t.maxRowHeight = n
+}
+
+// SetMaxRows Set the maximum number of rows printed
+// The omitted rows are counted in a last row spanning the table,
+// such as "… 1,284 more rows". Default is 0, no limit.
+func (t *Table) SetMaxRows(n int) {
+	t.maxRows = n
+}
+
+// SetEmptyMessage Set the text printed in a row spanning the table when it has no rows
+// such as "(no rows)". Default is empty, only the header and borders are printed.
+func (t *Table) SetEmptyMessage(msg string) {
+	t.emptyMessage = msg
+}
+
 // SetReflowDuringAutoWrap Turn automatic reflowing of multiline text when rewrapping. Default is on (true).
 func (t *Table) SetReflowDuringAutoWrap(auto bool) {
 	t.reflowText = auto
 }
 
+// SetWrapMode Set the line breaking used when wrapping cells and caption
+// WRAP_DEFAULT keeps the minimal raggedness of WrapString, WRAP_BALANCED
+// uses WrapStringBalanced to also even out the last line.
+func (t *Table) SetWrapMode(mode int) {
+	t.wrapMode = mode
+}
+
+// SetKeepURLsIntact Keep the http(s) URLs on one line when breaking long words
+// A URL wider than SetColMaxWidth then overflows the column instead of being
+// split across lines. Wrapping never splits words, URLs included. Default is
+// true.
+func (t *Table) SetKeepURLsIntact(keep bool) {
+	t.keepURLs = keep
+}
+
+// SetWrapFunc Set the function breaking cells and caption into lines
+// It replaces WrapString and the wrap mode, a nil func restores them.
+func (t *Table) SetWrapFunc(fn func(text string, width int) []string) {
+	t.wrapFunc = fn
+}
+
+// SetCellTransform Set a function changing the text of the cells as they are added
+// It runs before the column formatting and the width computation, row being
+// -1 for the header and -2 for the footer. It must be set before adding
+// content, a nil func removes it.
+func (t *Table) SetCellTransform(fn func(row, col int, value string) string) {
+	t.cellTransform = fn
+}
+
+// SetWidthFunc Set the function measuring the display width of the text
+// It replaces DisplayWidth for wrapping, padding and truncating, a nil func
+// restores it. It must be set before adding content.
+func (t *Table) SetWidthFunc(fn func(string) int) {
+	t.widthFunc = fn
+}
+
 // SetColWidth Set the Default column width
 func (t *Table) SetColWidth(width int) {
 	t.mW = width
 }
 
+// SetMinWidth Set the minimal width of the whole table
+// The last column is widened when the table is narrower than width
+func (t *Table) SetMinWidth(width int) {
+	t.minWidth = width
+}
+
+// SetMaxTableWidthStrict Set the maximum width of the whole table
+// When the table is wider, Render and the other Render methods print
+// nothing and Err returns the error. No column is shrunk to fit, the cells
+// are wrapped at Append time to SetColWidth and SetColMaxWidth. Default is
+// 0, no limit.
+func (t *Table) SetMaxTableWidthStrict(width int) {
+	t.maxWidthStrict = width
+}
+
+// SetElideColumns Drop the rightmost columns exceeding SetMaxTableWidthStrict
+// The columns that do not fit are replaced by a last column filled with the
+// truncation indicator, so the table keeps the leftmost columns visible
+// instead of failing to render. The first column is always kept. Only the
+// output is elided, the columns stay in the table for the next render.
+func (t *Table) SetElideColumns(elide bool) {
+	t.elide = elide
+}
+
+// SetRowRenderedCallback Set a function called after each row is printed
+// It receives the index of the row and does not change the output, which
+// makes it suitable for progress reporting on long renders.
+func (t *Table) SetRowRenderedCallback(fn func(rowIdx int)) {
+	t.rowRendered = fn
+}
+
+// SetHeaderColWidth Set the wrapping width of a header cell
+// It replaces the default column width for that header only and must
+// be set before SetHeader
+func (t *Table) SetHeaderColWidth(column int, width int) {
+	t.headerCs[column] = width
+}
+
 // SetColMinWidth Set the minimal width for a column
+// Narrower cells are padded to width. Nothing is changed when width exceeds
+// the maximal width of the column, which is reported by Err.
 func (t *Table) SetColMinWidth(column int, width int) {
-	t.cs[column] = width
+	if max, ok := t.columnsMaxWidth[column]; ok && width > max {
+		t.settingErr = fmt.Errorf("column %d: minimal width %d exceeds the maximal width %d", column, width, max)
+		return
+	}
+	t.columnsMinWidth[column] = width
+	if width > t.cs[column] {
+		t.cs[column] = width
+	}
+}
+
+// SetColMaxWidth Set the maximal width for a column
+// The cells of the column added afterwards are wrapped to width, breaking the
+// longer words but not the URLs, see SetKeepURLsIntact. Nothing is changed when width is below 1 or
+// the minimal width of the column, which is reported by Err.
+func (t *Table) SetColMaxWidth(column int, width int) {
+	if width < 1 {
+		t.settingErr = fmt.Errorf("column %d: invalid maximal width %d", column, width)
+		return
+	}
+	if min, ok := t.columnsMinWidth[column]; ok && min > width {
+		t.settingErr = fmt.Errorf("column %d: minimal width %d exceeds the maximal width %d", column, min, width)
+		return
+	}
+	t.columnsMaxWidth[column] = width
+}
+
+// AlignTables Give the same width to the columns of several tables
+// Each column is widened to the widest of that column across the tables, so
+// the tables line up when rendered one below the other. Call it once the
+// rows are appended, rows appended afterwards may widen a table again.
+func AlignTables(tables ...*Table) {
+	widths := make(map[int]int)
+	for _, t := range tables {
+		for col := 0; col < len(t.cs); col++ {
+			if t.cs[col] > widths[col] {
+				widths[col] = t.cs[col]
+			}
+		}
+	}
+	for _, t := range tables {
+		for col := 0; col < len(t.cs); col++ {
+			t.cs[col] = widths[col]
+		}
+	}
 }
 
 // SetColumnSeparator Set the Column Separator
@@ -227,6 +923,34 @@ func (t *Table) SetColumnSeparator(sep string) {
 	t.syms = simpleSyms(t.pCenter, t.pRow, t.pColumn)
 }
 
+// SetColumnLeftBorder Set a heavy separator on the left of a column
+// This sets apart the first columns, like frozen columns in a spreadsheet.
+// With regular unicode lines the heavy box drawing symbols are used,
+// otherwise the separator is doubled.
+func (t *Table) SetColumnLeftBorder(col int, heavy bool) {
+	t.columnHeavy[col] = heavy
+}
+
+// SetGutterColumns Insert blank spacer columns at the given indexes
+// The indexes are those of the rendered table, the rows, header and footer
+// do not hold any cell for them, and the other per column settings keep
+// applying to the same data columns. A gutter has no text and is a single
+// character wide.
+func (t *Table) SetGutterColumns(cols []int) {
+	t.gutters = make(map[int]bool, len(cols))
+	for _, col := range cols {
+		if col > 0 {
+			t.gutters[col] = true
+		}
+	}
+}
+
+// SetColumnSeparatorAt Set the Column Separator on the left of a column
+// This overrides the column separator between col-1 and col only
+func (t *Table) SetColumnSeparatorAt(col int, sep string) {
+	t.columnSeps[col] = sep
+}
+
 // SetRowSeparator Set the Row Separator
 func (t *Table) SetRowSeparator(sep string) {
 	t.pRow = sep
@@ -239,11 +963,59 @@ func (t *Table) SetCenterSeparator(sep string) {
 	t.syms = simpleSyms(t.pCenter, t.pRow, t.pColumn)
 }
 
+// SetCornerSymbols Set the symbols of the four outer corners
+// Setting the center, row or column separator afterwards resets them
+func (t *Table) SetCornerSymbols(topLeft, topRight, bottomLeft, bottomRight string) {
+	t.syms[symES] = topLeft
+	t.syms[symSW] = topRight
+	t.syms[symNE] = bottomLeft
+	t.syms[symNW] = bottomRight
+}
+
 // SetHeaderAlignment Set Header Alignment
 func (t *Table) SetHeaderAlignment(hAlign int) {
 	t.hAlign = hAlign
 }
 
+// SetHeaderFollowsColumnAlign Align each header cell like its column
+// The alignment is the one ColumnAlignments gives, numbers detected
+// included. SetHeaderColumnAlignment entries still take precedence.
+func (t *Table) SetHeaderFollowsColumnAlign(follow bool) {
+	t.headerFollowAlign = follow
+}
+
+// headerAlignments - alignment of the header cell of each column
+func (t *Table) headerAlignments() []int {
+	var columns []int
+	if t.headerFollowAlign {
+		columns = t.columnAlignments()
+	}
+	aligns := make([]int, len(t.cs))
+	for col := range aligns {
+		switch {
+		case col < len(t.headerColumnsAlign) && t.headerColumnsAlign[col] != ALIGN_DEFAULT:
+			aligns[col] = t.headerColumnsAlign[col]
+		case columns != nil:
+			aligns[col] = columns[col]
+		default:
+			aligns[col] = t.hAlign
+		}
+	}
+	return aligns
+}
+
+// SetFooterSeparator Set if a line separates the footer from the rows
+// It is printed by default, whatever the bottom border.
+func (t *Table) SetFooterSeparator(sep bool) {
+	t.footerSeparator = sep
+}
+
+// SetFooterPosition Set Footer Position
+// With POSITION_TOP the footer is printed between the header and the rows
+func (t *Table) SetFooterPosition(position int) {
+	t.footerPosition = position
+}
+
 // SetFooterAlignment Set Footer Alignment
 func (t *Table) SetFooterAlignment(fAlign int) {
 	t.fAlign = fAlign
@@ -259,13 +1031,123 @@ func (t *Table) SetNoWhiteSpace(allow bool) {
 	t.noWhiteSpace = allow
 }
 
+// SetColumnTSpacing Print the table like the output of `column -t`
+// The cells are left aligned and the columns separated by n spaces, without
+// borders nor lines, and the trailing spaces are trimmed. The header, when
+// set, is printed as it is on the first line. Like `column -t` the long
+// cells are not wrapped.
+func (t *Table) SetColumnTSpacing(n int) {
+	if n < 1 {
+		n = 1
+	}
+	t.SetAutoWrapText(false)
+	t.SetBorder(false)
+	t.SetHeaderLine(false)
+	t.SetRowLine(false)
+	t.SetAutoFormatHeaders(false)
+	t.SetAlignment(ALIGN_LEFT)
+	t.SetHeaderAlignment(ALIGN_LEFT)
+	t.SetFooterAlignment(ALIGN_LEFT)
+	t.SetNoWhiteSpace(true)
+	t.SetTablePadding(strings.Repeat(SPACE, n))
+	t.SetTrimTrailingSpace(true)
+}
+
+// SetCellSpacing Set the number of spaces around the content of a cell
+// Default is 1. The column separators and the border are kept.
+func (t *Table) SetCellSpacing(n int) {
+	if n < 0 {
+		n = 0
+	}
+	t.cellSpacing = n
+}
+
+// SetTrimTrailingSpace Remove the spaces at the end of the printed lines
+// Without a right border the padding of the last column, including the
+// wrapped lines of a cell, is then not printed.
+func (t *Table) SetTrimTrailingSpace(trim bool) {
+	t.trimTrailingSpace = trim
+}
+
+// SetDeterministic Make the output stable for golden file comparisons
+// Trailing spaces are trimmed and lines end with "\n" whatever SetNewLine.
+// Colors are never guessed from the terminal, only those set are used,
+// even when NO_COLOR is set.
+func (t *Table) SetDeterministic(deterministic bool) {
+	t.deterministic = deterministic
+}
+
+// SetSelectionColumn Add a first column showing which rows are selected
+// A row i is shown with checked when selected[i] is true and with
+// unchecked otherwise. The column is added when the table is rendered.
+func (t *Table) SetSelectionColumn(selected map[int]bool, checked, unchecked string) {
+	if selected == nil {
+		selected = map[int]bool{}
+	}
+	t.selection = selected
+	t.selectionGlyphs = [2]string{checked, unchecked}
+}
+
+// SetCollapseBlankRows Omit the rows whose cells are all blank
+// The blank rows are removed from the table when it is rendered.
+func (t *Table) SetCollapseBlankRows(collapse bool) {
+	t.collapseBlank = collapse
+}
+
+// SetDropEmptyColumns Omit the columns without any content when rendering
+// A column is dropped when its header, footer and all its cells are blank.
+// The dropped columns are removed from the table, rows have to be
+// appended before rendering.
+func (t *Table) SetDropEmptyColumns(drop bool) {
+	t.dropEmptyColumns = drop
+}
+
+// SetEscapeSeparator Escape the column separator found in cell text
+// Each occurrence is prefixed with a backslash, as in Markdown tables.
+// The separator must be set before the rows are appended.
+func (t *Table) SetEscapeSeparator(escape bool) {
+	t.escapeSeparator = escape
+}
+
 // SetTablePadding Set Table Padding
 func (t *Table) SetTablePadding(padding string) {
 	t.tablePadding = padding
 }
 
 // SetColumnAlignment Set Column Alignment
+// The entries replace the previous ones. Columns without an entry use the
+// global alignment. Once the header or a row is set, entries beyond the last
+// column are dropped and reported by Err.
 func (t *Table) SetColumnAlignment(keys []int) {
+	t.columnsAlign = normalizeAlignment(keys)
+	if len(t.cs) > 0 && len(t.columnsAlign) > len(t.cs) {
+		t.columnsAlign = t.columnsAlign[:len(t.cs)]
+		t.settingErr = fmt.Errorf("%d column alignments for %d columns", len(keys), len(t.cs))
+	}
+}
+
+// SetColumnAlignmentByName Set the alignment of the column with the given header
+// The name is matched against the header text, ignoring case.
+// An error is returned when no header has this name.
+func (t *Table) SetColumnAlignmentByName(name string, align int) error {
+	name = strings.Join(strings.Fields(name), SPACE)
+	for i, lines := range t.headers {
+		header := strings.Join(strings.Fields(strings.Join(lines, SPACE)), SPACE)
+		if !strings.EqualFold(header, name) {
+			continue
+		}
+		for len(t.columnsAlign) < len(t.headers) {
+			t.columnsAlign = append(t.columnsAlign, t.align)
+		}
+		t.columnsAlign[i] = normalizeAlignment([]int{align})[0]
+		return nil
+	}
+	return fmt.Errorf("unknown column %q", name)
+}
+
+// normalizeAlignment - replace unknown alignment values with ALIGN_DEFAULT
+func normalizeAlignment(keys []int) []int {
+	aligns := make([]int, 0, len(keys))
 	for _, v := range keys {
 		switch v {
 		case ALIGN_CENTER:
@@ -277,33 +1159,220 @@ func (t *Table) SetColumnAlignment(keys []int) {
 		default:
 			v = ALIGN_DEFAULT
 		}
-		t.columnsAlign = append(t.columnsAlign, v)
+		aligns = append(aligns, v)
 	}
+	return aligns
 }
 
-// SetNewLine Set New Line
-func (t *Table) SetNewLine(nl string) {
-	t.newLine = nl
+// ParseAlignment Get the alignment constant named by s
+// The names are "left", "right", "center" and "default", in any case.
+// "none" and the empty string are also ALIGN_DEFAULT.
+func ParseAlignment(s string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "left":
+		return ALIGN_LEFT, nil
+	case "right":
+		return ALIGN_RIGHT, nil
+	case "center":
+		return ALIGN_CENTER, nil
+	case "default", "none", "":
+		return ALIGN_DEFAULT, nil
+	}
+	return ALIGN_DEFAULT, fmt.Errorf("unknown alignment %q", s)
 }
 
-// SetHeaderLine Set Header Line
-// This would enable / disable a line after the header
-func (t *Table) SetHeaderLine(line bool) {
-	t.hdrLine = line
+// SetColumnVerticalAlignment Set Column Vertical Alignment
+// This places the lines of a cell at the top, middle or bottom of a
+// multi-line row. Columns without an entry are aligned at the top.
+func (t *Table) SetColumnVerticalAlignment(keys []int) {
+	t.columnsVAlign = make([]int, 0, len(keys))
+	for _, v := range keys {
+		switch v {
+		case VALIGN_MIDDLE, VALIGN_BOTTOM:
+		default:
+			v = VALIGN_TOP
+		}
+		t.columnsVAlign = append(t.columnsVAlign, v)
+	}
 }
 
-// SetRowLine Set Row Line
-// This would enable / disable a line on each row of the table
-func (t *Table) SetRowLine(line bool) {
-	t.rowLine = line
+// SetHeaderColumnAlignment Set Header Alignment per column
+// It is independent from the alignment of the rows, so a left aligned column
+// can have a centered header. Columns without an entry or with ALIGN_DEFAULT
+// fall back to the global header alignment
+func (t *Table) SetHeaderColumnAlignment(keys []int) {
+	t.headerColumnsAlign = normalizeAlignment(keys)
 }
 
-// SetAutoMergeCells Set Auto Merge Cells
+// SetFooterColumnAlignment Set Footer Alignment per column
+// Columns without an entry or with ALIGN_DEFAULT fall back to the global
+// footer alignment
+func (t *Table) SetFooterColumnAlignment(keys []int) {
+	t.footerColumnsAlign = normalizeAlignment(keys)
+}
+
+// SetColumnType Set the type of a column for the default alignment
+// TYPE_TEXT aligns left, TYPE_NUMBER aligns right and TYPE_AUTO detects numbers
+func (t *Table) SetColumnType(col int, ct ColumnType) {
+	t.columnsType[col] = ct
+}
+
+// SetEmptyPlaceholder Set the text of empty cells in rows appended afterwards
+// Only empty strings are replaced, cells made of spaces are kept
+func (t *Table) SetEmptyPlaceholder(s string) {
+	t.emptyPlaceholder = s
+}
+
+// SetStructsNilText Set the text of the nil fields in SetStructs
+// Nil pointers and interfaces are written "nil" by default. An empty text
+// gives an empty cell, replaced by the text of SetEmptyPlaceholder if set.
+func (t *Table) SetStructsNilText(s string) {
+	t.nilText = s
+}
+
+// SetStructsTimeFormat Set the layout of the time.Time fields in SetStructs
+// The layout is a Go time layout, such as time.RFC3339 or
+// "2006-01-02T15:04:05.000Z07:00" for milliseconds. By default the times
+// are written with their String method.
+func (t *Table) SetStructsTimeFormat(layout string) {
+	t.timeLayout = layout
+}
+
+// SetStructsTimeLocation Set the location of the times of SetStructsTimeFormat
+// The times are converted to loc, which can be time.UTC, before formatting.
+// A nil location keeps the location of each time.
+func (t *Table) SetStructsTimeLocation(loc *time.Location) {
+	t.timeLocation = loc
+}
+
+// SetColumnUnitSplit Line up values with units such as "5 ms" or "3 s"
+// Each cell of the column is split at the first space: the number is
+// aligned right and the unit left, so the numbers and units stack.
+func (t *Table) SetColumnUnitSplit(col int, split bool) {
+	if split {
+		t.columnsUnitSplit[col] = true
+	} else {
+		delete(t.columnsUnitSplit, col)
+	}
+}
+
+// SetDecimalSeparator Set the decimal separator used to detect and format numbers
+// With ',' the numbers are written like "1.234,50". Default is '.'.
+func (t *Table) SetDecimalSeparator(r rune) {
+	t.decimalSep = r
+}
+
+// SetNegativeStyle Set how negative numbers are shown in the rows
+// Use NEGATIVE_RED, NEGATIVE_PARENS or NEGATIVE_BOTH, 0 turns it off.
+func (t *Table) SetNegativeStyle(style int) {
+	t.negativeStyle = style & NEGATIVE_BOTH
+}
+
+// SetColumnDecimals Set the number of decimals for a column
+// Cells of the column that parse as floats are reformatted to the given
+// precision and aligned to the right. Other cells are left unchanged.
+func (t *Table) SetColumnDecimals(col int, places int) {
+	t.columnsDecimals[col] = places
+}
+
+// SetColumnHumanize Write the numbers of a column in compact form
+// Cells of the column that parse as floats are written with a K, M, B or T
+// suffix, like "1.5M" for 1500000, and aligned to the right.
+func (t *Table) SetColumnHumanize(col int) {
+	t.columnsHumanize[col] = true
+}
+
+// SetColumnHumanizeBytes Write the byte sizes of a column with a unit
+// Cells of the column that parse as floats are written like "1.5 GiB" and
+// aligned to the right. See SetHumanizeBytesSI for the units.
+func (t *Table) SetColumnHumanizeBytes(col int) {
+	t.columnsBytes[col] = true
+}
+
+// SetHumanizeBytesSI Use SI units with SetColumnHumanizeBytes
+// SI units are powers of 1000, like "1.5 GB", instead of the default IEC
+// units which are powers of 1024, like "1.5 GiB".
+func (t *Table) SetHumanizeBytesSI(si bool) {
+	t.humanizeBytesSI = si
+}
+
+// SetColumnHumanizeDuration Write the durations of a column in compact form
+// Cells of the column that parse with time.ParseDuration, such as the
+// output of time.Duration.String, are written like "1h23m" or "450ms" and
+// aligned to the right.
+func (t *Table) SetColumnHumanizeDuration(col int) {
+	t.columnsDuration[col] = true
+}
+
+// SetHumanizePrecision Set the maximum number of decimals of SetColumnHumanize
+// and SetColumnHumanizeBytes. Trailing zeros are dropped. Default is 1.
+func (t *Table) SetHumanizePrecision(places int) {
+	if places < 0 {
+		places = 0
+	}
+	t.humanizePrecision = places
+}
+
+// SetNewLine Set New Line
+func (t *Table) SetNewLine(nl string) {
+	t.newLine = nl
+}
+
+// SetHeaderLine Set Header Line
+// This would enable / disable a line after the header
+func (t *Table) SetHeaderLine(line bool) {
+	t.hdrLine = line
+}
+
+// SetColumnLine Set Column Line
+// This would enable / disable the lines between the columns, the outer
+// border and the row lines are kept. Default is true.
+func (t *Table) SetColumnLine(line bool) {
+	t.columnLine = line
+}
+
+// SetRowLine Set Row Line
+// This would enable / disable a line on each row of the table
+func (t *Table) SetRowLine(line bool) {
+	t.rowLine = line
+}
+
+// SetRowLineStyle Set Row Line Style
+// This would draw the lines between rows solid, dashed or dotted
+// The header line and the outer border are always solid
+func (t *Table) SetRowLineStyle(style int) {
+	switch style {
+	case LINE_DASHED, LINE_DOTTED:
+		t.rowLineStyle = style
+	default:
+		t.rowLineStyle = LINE_SOLID
+	}
+}
+
+// SetGrid Set Grid
+// This would give all the columns the same width and center every cell,
+// enabling the border and the row lines, for matrix display
+func (t *Table) SetGrid(grid bool) {
+	t.grid = grid
+	if grid {
+		t.EnableBorder(true)
+		t.SetRowLine(true)
+	}
+}
+
+// SetAutoMergeCells Set Auto Merge Cells
 // This would enable / disable the merge of cells with identical values
 func (t *Table) SetAutoMergeCells(auto bool) {
 	t.autoMergeCells = auto
 }
 
+// SetAutoMergeCellsHorizontal Set Auto Merge Cells Horizontally
+// This would enable / disable the merge of identical cells of a row into
+// one cell spanning their columns. It is not used with SetAutoMergeCells.
+func (t *Table) SetAutoMergeCellsHorizontal(auto bool) {
+	t.autoMergeHorizontal = auto
+}
+
 // SetAutoMergeCellsByColumnIndex Set Auto Merge Cells By Column Index
 // This would enable / disable the merge of cells with identical values for specific columns
 // If cols is empty, it is the same as `SetAutoMergeCells(true)`.
@@ -343,7 +1412,17 @@ func (t *Table) SetBorders(border Border) {
 // The field of the first element of the slice is used as the header.
 // If the element implements fmt.Stringer, the result will be used.
 // And the slice contains nil, it will be skipped without rendering.
+// Numeric fields are aligned right and string or bool fields left,
+// unless a column alignment was already set.
 func (t *Table) SetStructs(v interface{}) error {
+	return t.SetStructsWithTag(v, "tablewriter")
+}
+
+// SetStructsWithTag sets header and rows from slice of struct like SetStructs,
+// the header being taken from the tag with the given name, such as "json".
+// Tag options after a comma are ignored and a missing, empty or "-" tag
+// falls back to the field name.
+func (t *Table) SetStructsWithTag(v interface{}, tagName string) error {
 	if v == nil {
 		return errors.New("nil value")
 	}
@@ -374,15 +1453,24 @@ func (t *Table) SetStructs(v interface{}) error {
 		}
 		n := e.NumField()
 		headers := make([]string, n)
+		aligns := make([]int, n)
 		for i := 0; i < n; i++ {
 			f := e.Field(i)
-			header := f.Tag.Get("tablewriter")
-			if header == "" {
+			header := f.Tag.Get(tagName)
+			if i := strings.Index(header, ","); i >= 0 {
+				header = header[:i]
+			}
+			if header == "" || header == "-" {
 				header = f.Name
 			}
 			headers[i] = header
+			aligns[i] = kindAlignment(f.Type)
 		}
 		t.SetHeader(headers)
+		// Keep any alignment set by the caller
+		if len(t.columnsAlign) == 0 {
+			t.SetColumnAlignment(aligns)
+		}
 
 		for i := 0; i < vv.Len(); i++ {
 			item := reflect.Indirect(vv.Index(i))
@@ -403,19 +1491,7 @@ func (t *Table) SetStructs(v interface{}) error {
 			}
 			rows := make([]string, nf)
 			for j := 0; j < nf; j++ {
-				f := reflect.Indirect(item.Field(j))
-				if f.Kind() == reflect.Ptr {
-					f = f.Elem()
-				}
-				if f.IsValid() {
-					if s, ok := f.Interface().(fmt.Stringer); ok {
-						rows[j] = s.String()
-						continue
-					}
-					rows[j] = fmt.Sprint(f)
-				} else {
-					rows[j] = "nil"
-				}
+				rows[j] = t.fieldString(item.Field(j))
 			}
 			t.Append(rows)
 		}
@@ -425,6 +1501,74 @@ func (t *Table) SetStructs(v interface{}) error {
 	return nil
 }
 
+// fieldString - string of a struct field for SetStructs
+// Pointers and interfaces are followed down to the value, using the first
+// fmt.Stringer met on the way. Nil at any level gives the nil text.
+func (t *Table) fieldString(f reflect.Value) string {
+	for {
+		if !f.IsValid() {
+			return t.nilText
+		}
+		switch f.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if f.IsNil() {
+				return t.nilText
+			}
+		}
+		if f.CanInterface() {
+			if s, ok := t.timeString(f.Interface()); ok {
+				return s
+			}
+			if s, ok := f.Interface().(fmt.Stringer); ok {
+				return s.String()
+			}
+		}
+		switch f.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			f = f.Elem()
+		default:
+			return fmt.Sprint(f)
+		}
+	}
+}
+
+// timeString - format a time of SetStructs with SetStructsTimeFormat
+func (t *Table) timeString(v interface{}) (string, bool) {
+	if t.timeLayout == "" {
+		return "", false
+	}
+	var tm time.Time
+	switch v := v.(type) {
+	case time.Time:
+		tm = v
+	case *time.Time:
+		tm = *v
+	default:
+		return "", false
+	}
+	if t.timeLocation != nil {
+		tm = tm.In(t.timeLocation)
+	}
+	return tm.Format(t.timeLayout), true
+}
+
+// kindAlignment - alignment of a struct field based on its kind
+// Numbers are aligned right, strings and bools left.
+func kindAlignment(ft reflect.Type) int {
+	for ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+	switch ft.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return ALIGN_RIGHT
+	case reflect.String, reflect.Bool:
+		return ALIGN_LEFT
+	}
+	return ALIGN_DEFAULT
+}
+
 // Append row to table
 func (t *Table) Append(row []string) {
 	rowSize := len(t.headers)
@@ -445,6 +1589,76 @@ func (t *Table) Append(row []string) {
 		line = append(line, out)
 	}
 	t.lines = append(t.lines, line)
+	t.rawLines = append(t.rawLines, append([]string(nil), row...))
+}
+
+// AppendRow Append row to table and return its index
+func (t *Table) AppendRow(row []string) int {
+	t.Append(row)
+	return len(t.lines) - 1
+}
+
+// UpdateRow Replace the cells of the row at index i
+// Nothing is done if there is no such row. Columns may get wider but never
+// narrower, so the other rows keep their layout.
+func (t *Table) UpdateRow(i int, row []string) {
+	if i < 0 || i >= len(t.lines) {
+		return
+	}
+	delete(t.rs, i)
+	delete(t.rowParams, i)
+	line := [][]string{}
+	for col, v := range row {
+		line = append(line, t.parseDimension(v, col, i))
+	}
+	t.lines[i] = line
+	t.rawLines[i] = append([]string(nil), row...)
+}
+
+// AppendValues Append row to table from the given cells
+// It is a shorthand for Append when the cells are written inline
+func (t *Table) AppendValues(values ...string) {
+	t.Append(values)
+}
+
+// AppendLines Append row to table from cells already split into lines
+// The lines are kept as they are, without wrapping nor reflowing
+func (t *Table) AppendLines(row [][]string) {
+	rowSize := len(t.headers)
+	if rowSize > t.colSize {
+		t.colSize = rowSize
+	}
+
+	n := len(t.lines)
+	line := [][]string{}
+	raw := make([]string, len(row))
+	for i, v := range row {
+		line = append(line, t.parseLines(v, i, n))
+		raw[i] = strings.Join(v, nl)
+	}
+	t.lines = append(t.lines, line)
+	t.rawLines = append(t.rawLines, raw)
+}
+
+// AppendPreformatted Append row to table from pre-rendered cells
+// Each cell, like the output of another table, is split on newlines and
+// kept as it is, without wrapping nor reflowing
+func (t *Table) AppendPreformatted(row []string) {
+	cells := make([][]string, len(row))
+	for i, v := range row {
+		cells[i] = getLines(strings.TrimSuffix(v, nl))
+	}
+	t.AppendLines(cells)
+}
+
+// AppendMap Append row to table from a map
+// Values are taken in the order of SetHeaderFromKeys, missing keys are empty
+func (t *Table) AppendMap(m map[string]string) {
+	row := make([]string, len(t.headerKeys))
+	for i, key := range t.headerKeys {
+		row[i] = m[key]
+	}
+	t.Append(row)
 }
 
 // Rich Append row to table with color attributes
@@ -463,17 +1677,31 @@ func (t *Table) Rich(row []string, colors []Colors) {
 		// Break strings into words
 		out := t.parseDimension(v, i, n)
 
-		if len(colors) > i {
-			color := colors[i]
-			for idx := range out {
-				out[idx] = format(out[idx], color)
-			}
-		}
-
 		// Append broken words
 		line = append(line, out)
 	}
 	t.lines = append(t.lines, line)
+	t.rawLines = append(t.rawLines, append([]string(nil), row...))
+
+	// The colors are applied when the row is printed
+	params := make([]string, len(row))
+	for i := 0; i < len(colors) && i < len(row); i++ {
+		params[i] = makeSequence(colors[i])
+	}
+	if t.rowParams == nil {
+		t.rowParams = make(map[int][]string)
+	}
+	t.rowParams[n] = params
+}
+
+// RichFooter Set table Footer with color attributes
+// Cells without colors are printed as is.
+func (t *Table) RichFooter(row []string, colors []Colors) {
+	t.SetFooter(row)
+	t.footerParams = make([]string, len(t.footers))
+	for i := 0; i < len(colors) && i < len(t.footers); i++ {
+		t.footerParams[i] = makeSequence(colors[i])
+	}
 }
 
 // AppendBulk Allow Support for Bulk Append
@@ -484,19 +1712,59 @@ func (t *Table) AppendBulk(rows [][]string) {
 	}
 }
 
+// AppendFromReader Append a row for each line read from r
+// The lines are split on sep into cells, without any quoting, or on runs of
+// spaces and tabs when sep is empty. Blank lines are skipped. The error is
+// the first read error of r, nil at the end of the input.
+func (t *Table) AppendFromReader(r io.Reader, sep string) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) != "" {
+			if sep == "" {
+				t.Append(strings.Fields(line))
+			} else {
+				t.Append(strings.Split(line, sep))
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // NumLines to get the number of lines
 func (t *Table) NumLines() int {
 	return len(t.lines)
 }
 
+// AppendSectionLine Append a line with the label inset at its left
+// such as "+--- Section A ------+". The line is printed before the next
+// appended row, in place of the row line or the header line, and the label
+// hides the fill and junctions it covers.
+func (t *Table) AppendSectionLine(label string) {
+	if t.sections == nil {
+		t.sections = make(map[int]string)
+	}
+	t.sections[len(t.lines)] = label
+}
+
 // ClearRows Clear rows
 func (t *Table) ClearRows() {
 	t.lines = [][][]string{}
+	t.rawLines = nil
+	t.sections = nil
+	t.rowParams = nil
 }
 
 // ClearFooter Clear footer
 func (t *Table) ClearFooter() {
 	t.footers = [][]string{}
+	t.rawFooters = nil
 }
 
 // Center based on position and border.
@@ -506,12 +1774,12 @@ func (t *Table) center(i int, isFirstRow, isLastRow bool) string {
 			return t.syms[symEW]
 		}
 		if isFirstRow {
-			return t.syms[symES]
+			return t.fitSymbol(t.syms[symES], t.syms[symNS], SPACE, t.syms[symEW])
 		}
 		if isLastRow {
-			return t.syms[symNE]
+			return t.fitSymbol(t.syms[symNE], t.syms[symNS], SPACE, t.syms[symEW])
 		}
-		return t.syms[symNES]
+		return t.fitSymbol(t.syms[symNES], t.syms[symNS], SPACE, t.syms[symEW])
 	}
 
 	if i == len(t.cs)-1 {
@@ -519,58 +1787,183 @@ func (t *Table) center(i int, isFirstRow, isLastRow bool) string {
 			return t.syms[symEW]
 		}
 		if isFirstRow {
-			return t.syms[symSW]
+			return t.fitSymbol(t.syms[symSW], t.syms[symNS], t.syms[symEW], SPACE)
 		}
 		if isLastRow {
-			return t.syms[symNW]
+			return t.fitSymbol(t.syms[symNW], t.syms[symNS], t.syms[symEW], SPACE)
+		}
+		return t.fitSymbol(t.syms[symNSW], t.syms[symNS], t.syms[symEW], SPACE)
+	}
+
+	return t.junction(i, !isFirstRow, !isLastRow)
+}
+
+// junction - symbol of a line at the right of column i, going up and/or down
+func (t *Table) junction(i int, up, down bool) string {
+	// Without a visible column separator the junction is part of the rule
+	sep := t.columnSeparator(i + 1)
+	if strings.TrimSpace(sep) == "" || (!up && !down) {
+		return strings.Repeat(t.syms[symEW], t.width(sep))
+	}
+
+	junction := t.syms[symNESW]
+	if !up {
+		junction = t.syms[symESW]
+	} else if !down {
+		junction = t.syms[symNEW]
+	}
+	if t.isHeavyColumn(i + 1) {
+		junction = t.heavyJunction(junction, up, down)
+	}
+
+	return t.fitSymbol(junction, sep, t.syms[symEW], t.syms[symEW])
+}
+
+// fitSymbol - widen a line symbol to the width of the separator it crosses
+// Separators may be wider than one character, like " | ". The symbol is put
+// under the first visible character of sep, with left and right repeated on
+// its sides: the rule, or spaces on the outer side of a border.
+func (t *Table) fitSymbol(sym, sep, left, right string) string {
+	gap := t.width(sep) - t.width(sym)
+	if gap <= 0 {
+		return sym
+	}
+	lead := t.width(sep) - t.width(strings.TrimLeft(sep, SPACE))
+	if lead > gap {
+		lead = gap
+	}
+	return strings.Repeat(left, lead) + sym + strings.Repeat(right, gap-lead)
+}
+
+// isHeavyColumn - check if SetColumnLeftBorder applies to the left of col
+// A separator set with SetColumnSeparatorAt takes precedence.
+func (t *Table) isHeavyColumn(col int) bool {
+	if _, ok := t.columnSeps[col]; ok {
+		return false
+	}
+	return t.columnHeavy[col] && col > 0 && col < len(t.cs)
+}
+
+// isLightUnicode - check for the regular unicode box drawing lines
+func (t *Table) isLightUnicode() bool {
+	return t.syms[symEW] == "─" && t.syms[symNS] == "│"
+}
+
+// heavyJunction - junction of a line with a heavy column border
+// The unicode heavy vertical junctions are used with regular lines, other
+// styles double the junction like the separator.
+func (t *Table) heavyJunction(junction string, up, down bool) string {
+	if !t.isLightUnicode() {
+		return strings.Repeat(junction, 2)
+	}
+	switch {
+	case !up:
+		return "┰"
+	case !down:
+		return "┸"
+	}
+	return "╂"
+}
+
+// columnSeparator - the separator printed on the left of a column
+func (t *Table) columnSeparator(col int) string {
+	if sep, ok := t.columnSeps[col]; ok && col > 0 && col < len(t.cs) {
+		return sep
+	}
+	if t.isHeavyColumn(col) {
+		if t.isLightUnicode() {
+			return "┃"
 		}
-		return t.syms[symNSW]
+		return strings.Repeat(t.syms[symNS], 2)
+	}
+	if !t.columnLine && col > 0 && col < len(t.cs) {
+		return SPACE
 	}
+	return t.syms[symNS]
+}
 
-	if isFirstRow {
-		return t.syms[symESW]
+// printTopLine - print the top border, joined to the first row without header
+func (t *Table) printTopLine() {
+	var below map[int]bool
+	if len(t.headers) == 0 && t.footerPosition != POSITION_TOP {
+		below = t.spannedBoundaries(0)
 	}
-	if isLastRow {
-		return t.syms[symNEW]
+	t.printSpannedLine(true, false, LINE_SOLID, nil, below)
+}
+
+// printBottomLine - print the line closing the rows
+func (t *Table) printBottomLine() {
+	last := t.shownRows()
+	if t.spanningText() == "" {
+		last--
 	}
-	return t.syms[symNESW]
+	t.printSpannedLine(false, !t.hasBottomFooter(), LINE_SOLID, t.spannedBoundaries(last), nil)
 }
 
 // Print line based on row width
 func (t *Table) printLine(isFirst, isLast bool) {
+	t.printStyledLine(isFirst, isLast, LINE_SOLID)
+}
+
+// Print line based on row width, filling the columns with the given style
+func (t *Table) printStyledLine(isFirst, isLast bool, style int) {
+	t.printSpannedLine(isFirst, isLast, style, nil, nil)
+}
+
+// Print line based on row width, without junctions where the row above or
+// below has a cell spanning the columns. above and below are the spanned
+// boundaries, as returned by spannedBoundaries.
+func (t *Table) printSpannedLine(isFirst, isLast bool, style int, above, below map[int]bool) {
 	fmt.Fprint(t.out, t.center(-1, isFirst, isLast))
 	for i := 0; i < len(t.cs); i++ {
 		v := t.cs[i]
-		fmt.Fprintf(t.out, "%s%s%s%s",
-			t.syms[symEW],
-			strings.Repeat(t.syms[symEW], v),
-			t.syms[symEW],
-			t.center(i, isFirst, isLast))
+		center := t.center(i, isFirst, isLast)
+		if i < len(t.cs)-1 && (above[i] || below[i]) {
+			center = t.junction(i, !isFirst && !above[i], !isLast && !below[i])
+		}
+		fmt.Fprintf(t.out, "%s%s",
+			t.lineFill(v+2*t.cellSpacing, style),
+			center)
 	}
 	fmt.Fprint(t.out, t.newLine)
 }
 
+// lineFill - horizontal filling of the given width for a line style
+func (t *Table) lineFill(width int, style int) string {
+	switch style {
+	case LINE_DASHED:
+		fill := make([]string, width)
+		for i := range fill {
+			fill[i] = ConditionString(i%2 == 0, t.syms[symEW], SPACE)
+		}
+		return strings.Join(fill, "")
+	case LINE_DOTTED:
+		return strings.Repeat(DOT, width)
+	}
+	return strings.Repeat(t.syms[symEW], width)
+}
+
 // Print line based on row width with our without cell separator
 func (t *Table) printLineOptionalCellSeparators(nl bool, displayCellSeparator []bool) {
-	fmt.Fprint(t.out, t.syms[symNES])
-	centerSym := symNESW
+	// Without left border a merged first cell leaves the line open
+	left := t.center(-1, false, false)
+	if !t.borders.Left && len(displayCellSeparator) > 0 && !displayCellSeparator[0] {
+		left = SPACE
+	}
+	fmt.Fprint(t.out, left)
 	for i := 0; i < len(t.cs); i++ {
 		v := t.cs[i]
-		if i == len(t.cs)-1 {
-			centerSym = symNSW
-		}
+		center := t.center(i, false, false)
 		if i > len(displayCellSeparator) || displayCellSeparator[i] {
 			// Display the cell separator
-			fmt.Fprintf(t.out, "%s%s%s%s",
-				t.syms[symEW],
-				strings.Repeat(string(t.syms[symEW]), v),
-				t.syms[symEW],
-				t.syms[centerSym])
+			fmt.Fprintf(t.out, "%s%s",
+				t.lineFill(v+2*t.cellSpacing, t.rowLineStyle),
+				center)
 		} else {
 			// Don't display the cell separator for this cell
 			fmt.Fprintf(t.out, "%s%s",
-				strings.Repeat(" ", v+2),
-				t.syms[centerSym])
+				strings.Repeat(SPACE, v+2*t.cellSpacing),
+				center)
 		}
 	}
 	if nl {
@@ -580,19 +1973,66 @@ func (t *Table) printLineOptionalCellSeparators(nl bool, displayCellSeparator []
 
 // Return the PadRight function if align is left, PadLeft if align is right,
 // and Pad by default
-func pad(align int) func(string, string, int) string {
-	padFunc := Pad
+func (t *Table) pad(align int) func(string, string, int) string {
+	padFunc := padWidth
 	switch align {
 	case ALIGN_LEFT:
-		padFunc = PadRight
+		padFunc = padRightWidth
 	case ALIGN_RIGHT:
-		padFunc = PadLeft
+		padFunc = padLeftWidth
+	}
+	return func(s, pad string, width int) string {
+		return padFunc(s, pad, width, t.width)
 	}
-	return padFunc
+}
+
+// width - display width of s, measured with the function of SetWidthFunc
+func (t *Table) width(s string) int {
+	if t.widthFunc != nil {
+		return t.widthFunc(s)
+	}
+	return DisplayWidth(s)
+}
+
+// truncate - cut s to the display width w, ending with the indicator
+// The escape sequences before the cut are kept and the colors they set
+// are reset after the indicator.
+func (t *Table) truncate(s string, w int, indicator string) string {
+	if t.width(s) <= w {
+		return s
+	}
+	room := w - t.width(indicator)
+	escapes := ansi.FindAllStringIndex(s, -1)
+	colored := false
+	var b strings.Builder
+	for i, width := 0, 0; i < len(s); {
+		if len(escapes) > 0 && i == escapes[0][0] {
+			b.WriteString(s[i:escapes[0][1]])
+			i, escapes, colored = escapes[0][1], escapes[1:], true
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if width += t.width(string(r)); width > room {
+			break
+		}
+		b.WriteRune(r)
+		i += size
+	}
+	b.WriteString(indicator)
+	if colored {
+		b.WriteString("\033[0m")
+	}
+	return b.String()
 }
 
 // Print heading information
 func (t *Table) printHeading() {
+	t.printHeaderCells()
+	t.printHeaderLine()
+}
+
+// printHeaderCells - print the lines of the header, without the line under it
+func (t *Table) printHeaderCells() {
 	// Check if headers is available
 	if len(t.headers) < 1 {
 		return
@@ -601,69 +2041,157 @@ func (t *Table) printHeading() {
 	// Identify last column
 	end := len(t.cs) - 1
 
-	// Get pad function
-	padFunc := pad(t.hAlign)
-
 	// Checking for ANSI escape sequences for header
 	is_esc_seq := false
 	if len(t.headerParams) > 0 {
 		is_esc_seq = true
 	}
+	// Rows wider than the header get blank header cells
+	params := make([]string, len(t.cs))
+	copy(params, t.headerParams)
 
 	// Maximum height.
 	max := t.rs[headerRowIdx]
+	space := strings.Repeat(SPACE, t.cellSpacing)
+	aligns := t.headerAlignments()
 
 	// Print Heading
 	for x := 0; x < max; x++ {
+		// Buffer the line to apply the header background
+		line := &bytes.Buffer{}
+
 		// Check if border is set
 		// Replace with space if not set
 		if !t.noWhiteSpace {
-			fmt.Fprint(t.out, ConditionString(t.borders.Left, t.syms[symNS], SPACE))
+			fmt.Fprint(line, ConditionString(t.borders.Left, t.syms[symNS], SPACE))
 		}
 
 		for y := 0; y <= end; y++ {
 			v := t.cs[y]
 			h := ""
 
+			// Get pad function
+			padFunc := t.pad(aligns[y])
+
 			if y < len(t.headers) && x < len(t.headers[y]) {
 				h = t.headers[y][x]
 			}
 			if t.autoFmt {
-				h = Title(h)
+				h = t.headerText(h)
 			}
-			pad := ConditionString((y == end && !t.borders.Left), SPACE, t.syms[symNS])
+			pad := ConditionString((y == end && !t.borders.Right), SPACE, t.columnSeparator(y+1))
 			if t.noWhiteSpace {
-				pad = ConditionString((y == end && !t.borders.Left), SPACE, t.tablePadding)
+				pad = ConditionString((y == end && !t.borders.Right), SPACE, t.tablePadding)
 			}
 			if is_esc_seq {
 				if !t.noWhiteSpace {
-					fmt.Fprintf(t.out, " %s %s",
-						format(padFunc(h, SPACE, v),
-							t.headerParams[y]), pad)
+					fmt.Fprintf(line, "%s%s%s%s", space,
+						t.colorize(padFunc(h, SPACE, v),
+							params[y]), space, pad)
 				} else {
-					fmt.Fprintf(t.out, "%s %s",
-						format(padFunc(h, SPACE, v),
-							t.headerParams[y]), pad)
+					fmt.Fprintf(line, "%s %s",
+						t.colorize(padFunc(h, SPACE, v),
+							params[y]), pad)
 				}
 			} else {
 				if !t.noWhiteSpace {
-					fmt.Fprintf(t.out, " %s %s",
+					fmt.Fprintf(line, "%s%s%s%s", space,
 						padFunc(h, SPACE, v),
-						pad)
+						space, pad)
 				} else {
 					// the spaces between breaks the kube formatting
-					fmt.Fprintf(t.out, "%s%s",
+					fmt.Fprintf(line, "%s%s",
 						padFunc(h, SPACE, v),
 						pad)
 				}
 			}
 		}
+		fmt.Fprint(t.out, t.colorizeLine(line.String(), t.headerBgParams))
+
 		// Next line
 		fmt.Fprint(t.out, t.newLine)
 	}
-	if t.hdrLine {
-		t.printLine(false, false)
+}
+
+// printHeaderLine - print the line under the header
+func (t *Table) printHeaderLine() {
+	if len(t.headers) < 1 || !t.hdrLine || t.sectionUnderHeader() {
+		return
+	}
+	var below map[int]bool
+	if t.footerPosition != POSITION_TOP {
+		below = t.spannedBoundaries(0)
 	}
+	t.printSpannedLine(false, false, LINE_SOLID, nil, below)
+}
+
+// emptyBody - check if nothing is printed between the header and the bottom line
+func (t *Table) emptyBody() bool {
+	return len(t.lines) == 0 && t.spanningText() == "" && t.footerPosition != POSITION_TOP
+}
+
+// closedByTopFooter - check if the line under the top footer closes the table
+// Without rows, it takes the place of the bottom line.
+func (t *Table) closedByTopFooter() bool {
+	return len(t.lines) == 0 && t.spanningText() == "" && t.footerPosition == POSITION_TOP &&
+		len(t.footers) > 0 && t.footerSeparator
+}
+
+// headerLineDropped - check if the bottom line replaces the header line
+// Without rows, the header is directly followed by the bottom line.
+func (t *Table) headerLineDropped() bool {
+	return t.emptyBody() && t.hasBottomLine()
+}
+
+// hasSection - check if a section line is printed before the row
+func (t *Table) hasSection(rowIdx int) bool {
+	_, ok := t.sections[rowIdx]
+	return ok && rowIdx < t.shownRows()
+}
+
+// sectionUnderHeader - check if the first section line replaces the header line
+func (t *Table) sectionUnderHeader() bool {
+	return t.hasSection(0) && len(t.headers) > 0 && t.footerPosition != POSITION_TOP
+}
+
+// printSectionLine - print the section line before the row with its label
+func (t *Table) printSectionLine(rowIdx int) {
+	out := t.out
+	var buf bytes.Buffer
+	t.out = &buf
+	t.printSpannedLine(false, false, LINE_SOLID, t.spannedBoundaries(rowIdx-1), t.spannedBoundaries(rowIdx))
+	t.out = out
+	line := strings.TrimSuffix(buf.String(), t.newLine)
+
+	// The label starts after the left corner and three fill characters and
+	// keeps at least one of them before the right corner
+	lead := t.width(t.center(-1, false, false)) + 3
+	room := t.width(line) - lead - t.width(t.center(len(t.cs)-1, false, false)) - 3
+	label := strings.Join(strings.Fields(t.sections[rowIdx]), SPACE)
+	if t.width(label) > room {
+		label = t.truncate(label, room, t.truncateIndicator)
+	}
+	if label == "" || room < 1 {
+		fmt.Fprint(t.out, line, t.newLine)
+		return
+	}
+	label = SPACE + label + SPACE
+	head, rest := splitWidth(line, lead, t.width)
+	_, tail := splitWidth(rest, t.width(label), t.width)
+	fmt.Fprint(t.out, head, label, tail, t.newLine)
+}
+
+// splitWidth - cut s in two, the first part being w wide
+// A character across the cut goes to the second part.
+func splitWidth(s string, w int, sw func(string) int) (string, string) {
+	width := 0
+	for i, r := range s {
+		width += sw(string(r))
+		if width > w {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
 }
 
 // Print heading information
@@ -673,181 +2201,859 @@ func (t *Table) printFooter() {
 		return
 	}
 
-	// Only print line if border is not set
-	if !t.borders.Bottom {
-		t.printLine(false, false)
-	}
+	t.printFooterLines(false)
 
 	// Identify last column
 	end := len(t.cs) - 1
 
-	// Get pad function
-	padFunc := pad(t.fAlign)
+	hasPrinted := false
+
+	for i := 0; i <= end; i++ {
+		v := t.cs[i]
+		pad := t.syms[symEW]
+		center := t.syms[symNEW]
+		length := len(t.footers[i][0])
+
+		if length > 0 {
+			hasPrinted = true
+		}
+
+		// Set center to be space if length is 0
+		if length == 0 && !t.borders.Right {
+			center = SPACE
+		}
+
+		// Print first junction
+		if i == 0 {
+			if length > 0 && !t.borders.Left {
+				center = t.syms[symEW]
+			} else if center != SPACE {
+				center = t.syms[symNE]
+			}
+			fmt.Fprint(t.out, t.fitSymbol(center, ConditionString(t.borders.Left, t.syms[symNS], SPACE), SPACE, pad))
+		}
+
+		// Pad With space of length is 0
+		if length == 0 {
+			pad = SPACE
+		}
+		// Ignore left space as it has printed before
+		if hasPrinted || t.borders.Left {
+			pad = t.syms[symEW]
+			center = t.syms[symNEW]
+		}
+
+		// Change Center end position
+		if center != SPACE {
+			if i == end {
+				if t.borders.Right {
+					center = t.syms[symNW]
+				} else {
+					center = t.syms[symEW]
+				}
+			}
+		}
+
+		// Change Center start position
+		if center == SPACE {
+			if i < end && len(t.footers[i+1][0]) != 0 {
+				if !t.borders.Left {
+					center = t.syms[symEW]
+				} else {
+					center = t.syms[symNEW]
+				}
+			}
+		}
+
+		// Without a visible column separator the junction is part of the rule
+		if sep := t.columnSeparator(i + 1); i < end && center == t.syms[symNEW] && strings.TrimSpace(sep) == "" {
+			center = strings.Repeat(pad, t.width(sep))
+		}
+
+		if i < end && center == t.syms[symNEW] && t.isHeavyColumn(i+1) {
+			center = t.heavyJunction(center, true, false)
+		}
+
+		// Separators may be wider than the junction
+		if i < end {
+			center = t.fitSymbol(center, t.columnSeparator(i+1), pad, pad)
+		} else {
+			center = t.fitSymbol(center, ConditionString(t.borders.Right, t.syms[symNS], SPACE), pad, SPACE)
+		}
+
+		// Print the footer
+		fmt.Fprintf(t.out, "%s%s%s%s",
+			strings.Repeat(pad, t.cellSpacing),
+			strings.Repeat(string(pad), v),
+			strings.Repeat(pad, t.cellSpacing),
+			center)
+
+	}
+
+	fmt.Fprint(t.out, t.newLine)
+}
+
+// Print the footer cells
+// At the bottom, empty cells on the first line also erase their separator
+func (t *Table) printFooterLines(top bool) {
+	// Identify last column
+	end := len(t.cs) - 1
 
 	// Checking for ANSI escape sequences for header
 	is_esc_seq := false
 	if len(t.footerParams) > 0 {
 		is_esc_seq = true
 	}
+	// Rows wider than the footer get blank footer cells
+	params := make([]string, len(t.cs))
+	copy(params, t.footerParams)
 
 	// Maximum height.
 	max := t.rs[footerRowIdx]
+	space := strings.Repeat(SPACE, t.cellSpacing)
 
 	// Print Footer
-	for i := 0; i < (len(t.cs) - len(t.footers)); i++ {
-		lines := t.parseDimension(" ", len(t.footers), footerRowIdx)
-		t.footers = append(t.footers, lines)
+	// The missing cells are blank, whatever SetCellTransform
+	for len(t.footers) < len(t.cs) {
+		t.storeDimension(len(t.footers), footerRowIdx, 1, 1)
+		t.footers = append(t.footers, []string{SPACE})
 	}
+	left, right := t.borders.Left, t.borders.Right
+
 	erasePad := make([]bool, len(t.footers))
 	for x := 0; x < max; x++ {
 		// Check if border is set
 		// Replace with space if not set
-		fmt.Fprint(t.out, ConditionString(t.borders.Bottom, t.syms[symNS], SPACE))
+		fmt.Fprint(t.out, ConditionString(left, t.syms[symNS], SPACE))
 
 		for y := 0; y <= end; y++ {
 			v := t.cs[y]
 			f := ""
+
+			// Get pad function
+			padFunc := t.pad(t.fAlign)
+			if y < len(t.footerColumnsAlign) && t.footerColumnsAlign[y] != ALIGN_DEFAULT {
+				padFunc = t.pad(t.footerColumnsAlign[y])
+			}
+
 			if y < len(t.footers) && x < len(t.footers[y]) {
 				f = t.footers[y][x]
 			}
 			if t.autoFmt {
-				f = Title(f)
+				f = t.titleFunc(f)
 			}
-			pad := ConditionString((y == end && !t.borders.Top), SPACE, t.syms[symNS])
+			pad := ConditionString((y == end && !right), SPACE, t.columnSeparator(y+1))
 
-			if erasePad[y] || (x == 0 && len(f) == 0) {
-				pad = SPACE
+			if !top && (erasePad[y] || (x == 0 && len(f) == 0)) {
+				pad = strings.Repeat(SPACE, t.width(pad))
 				erasePad[y] = true
 			}
 
 			if is_esc_seq {
-				fmt.Fprintf(t.out, " %s %s",
-					format(padFunc(f, SPACE, v),
-						t.footerParams[y]), pad)
+				fmt.Fprintf(t.out, "%s%s%s%s", space,
+					t.colorize(padFunc(f, SPACE, v),
+						params[y]), space, pad)
 			} else {
-				fmt.Fprintf(t.out, " %s %s",
+				fmt.Fprintf(t.out, "%s%s%s%s", space,
 					padFunc(f, SPACE, v),
-					pad)
+					space, pad)
 			}
 
-			//fmt.Fprintf(t.out, " %s %s",
-			//	padFunc(f, SPACE, v),
-			//	pad)
+			//fmt.Fprintf(t.out, " %s %s",
+			//	padFunc(f, SPACE, v),
+			//	pad)
+		}
+		// Next line
+		fmt.Fprint(t.out, t.newLine)
+	}
+}
+
+// Print the footer under the header, followed by a line to the rows
+func (t *Table) printTopFooter() {
+	if len(t.footers) < 1 {
+		return
+	}
+	t.printFooterLines(true)
+	if t.footerSeparator {
+		t.printLine(false, false)
+	}
+}
+
+// hasBottomLine - check if a line is printed under the rows
+// It is either the bottom border or the line above the footer
+func (t *Table) hasBottomLine() bool {
+	if t.hasBottomFooter() {
+		return t.footerSeparator
+	}
+	return t.borders.Bottom
+}
+
+// hasBottomFooter - check if a footer is printed under the rows
+func (t *Table) hasBottomFooter() bool {
+	return len(t.footers) > 0 && t.footerPosition != POSITION_TOP
+}
+
+// Print caption text
+func (t *Table) printCaption() {
+	width := t.getTableWidth()
+	if t.captionBoxed {
+		t.printCaptionBox(width)
+		return
+	}
+	paragraph, _ := t.wrapString(t.captionText, width)
+	for linecount := 0; linecount < len(paragraph); linecount++ {
+		fmt.Fprintln(t.out, paragraph[linecount])
+	}
+}
+
+// printLegend - print the legend under the table
+func (t *Table) printLegend() {
+	for _, line := range t.legendLines() {
+		fmt.Fprint(t.out, line, t.newLine)
+	}
+}
+
+// legendLines - entries of the legend filling lines of the table width
+// The entries are separated by two spaces.
+func (t *Table) legendLines() []string {
+	width := t.getTableWidth()
+	if width < t.minWidth {
+		width = t.minWidth
+	}
+	var lines []string
+	line := ""
+	for _, item := range t.legend {
+		item = strings.Join(strings.Fields(item), SPACE)
+		if item == "" {
+			continue
+		}
+		if line != "" && t.width(line)+2+t.width(item) <= width {
+			line += "  " + item
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = item
+		if parts, _ := t.wrapString(item, width); t.width(item) > width && len(parts) > 0 {
+			lines = append(lines, parts[:len(parts)-1]...)
+			line = parts[len(parts)-1]
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// printCaptionBox - print the caption text framed at the given width
+func (t *Table) printCaptionBox(width int) {
+	inner := width - 4
+	if inner < 1 {
+		inner = 1
+	}
+	paragraph, _ := t.wrapString(t.captionText, inner)
+	fill := strings.Repeat(t.syms[symEW], inner+2)
+	fmt.Fprint(t.out, t.syms[symES], fill, t.syms[symSW], t.newLine)
+	for _, line := range paragraph {
+		fmt.Fprint(t.out, t.syms[symNS], SPACE, t.pad(ALIGN_LEFT)(line, SPACE, inner),
+			SPACE, t.syms[symNS], t.newLine)
+	}
+	fmt.Fprint(t.out, t.syms[symNE], fill, t.syms[symNW], t.newLine)
+}
+
+// RenderedHeight Calculate the number of lines Render would print
+// This includes borders, header, wrapped rows, footer and caption
+func (t *Table) RenderedHeight() int {
+	defer t.keepLayout()()
+	t.fillWidths()
+	return t.renderedHeight()
+}
+
+// renderedHeight - number of lines printed with the widths already filled
+func (t *Table) renderedHeight() int {
+	height := 0
+	if t.borders.Top {
+		height++
+	}
+	if len(t.headers) > 0 {
+		height += t.rs[headerRowIdx]
+		if t.hdrLine && !t.headerLineDropped() {
+			height++
+		}
+	}
+	for i := 0; i < t.shownRows(); i++ {
+		height += t.rs[i]
+		if t.rowLine {
+			height++
+		}
+		// A section line takes the place of the row line or header line
+		if t.hasSection(i) && !(i > 0 && t.rowLine) && !(i == 0 && t.hdrLine && t.sectionUnderHeader()) {
+			height++
+		}
+	}
+	if t.spanningText() != "" {
+		height++
+		if t.rowLine {
+			height++
+		}
+	}
+	if t.emptyBody() {
+		if t.hasBottomLine() {
+			height++
+		}
+	} else if len(t.lines) == 0 && t.spanningText() == "" {
+		// Without rows the top footer is followed by the bottom line
+		if t.hasBottomLine() && !t.closedByTopFooter() {
+			height++
+		}
+	} else if t.rowLine && !t.hasBottomLine() {
+		// The line after the last row is the missing bottom border
+		height--
+	} else if !t.rowLine && t.hasBottomLine() {
+		height++
+	}
+	if len(t.footers) > 0 {
+		// Line between the footer and the rows, or closing line
+		height += t.rs[footerRowIdx]
+		if t.footerSeparator || t.footerPosition != POSITION_TOP {
+			height++
+		}
+	}
+	if t.caption {
+		width := t.getTableWidth()
+		if width < t.minWidth {
+			width = t.minWidth
+		}
+		if t.captionBoxed {
+			width -= 4
+			height += 2
+		}
+		paragraph, _ := t.wrapString(t.captionText, width)
+		height += len(paragraph)
+	}
+	return height + len(t.legendLines())
+}
+
+// Calculate the total number of characters in a row
+func (t *Table) getTableWidth() int {
+	var chars int
+	for _, v := range t.cs {
+		chars += v
+	}
+	// Without white space the columns are only separated by the table padding
+	if t.noWhiteSpace {
+		if len(t.cs) > 1 {
+			chars += t.width(t.tablePadding) * (len(t.cs) - 1)
+		}
+		return chars
+	}
+
+	// Add chars, spaces, seperators to calculate the total width of the table.
+	// ncols := len(t.cs)
+	// spaces := ncols * 2 * t.cellSpacing
+	// seps := ncols + 1
+
+	// Custom separators may be wider than one character, the outer borders too.
+	for col := 1; col < len(t.cs); col++ {
+		chars += t.width(t.columnSeparator(col)) - 1
+	}
+	if t.borders.Left {
+		chars += t.width(t.syms[symNS]) - 1
+	}
+	if t.borders.Right {
+		chars += t.width(t.syms[symNS]) - 1
+	}
+
+	return (chars + ((2*t.cellSpacing + 1) * len(t.cs)) + 1)
+}
+
+// collapseBlankRows - remove the blank rows when SetCollapseBlankRows is on
+func (t *Table) collapseBlankRows() {
+	if !t.collapseBlank {
+		return
+	}
+	lines := t.lines[:0]
+	rs := make(map[int]int, len(t.rs))
+	rs[headerRowIdx], rs[footerRowIdx] = t.rs[headerRowIdx], t.rs[footerRowIdx]
+	var selection map[int]bool
+	if t.selection != nil {
+		selection = make(map[int]bool)
+	}
+	// A section line before a blank row moves to the next row
+	var sections map[int]string
+	if t.sections != nil {
+		sections = make(map[int]string)
+	}
+	rowParams := make(map[int][]string, len(t.rowParams))
+	for i, row := range t.lines {
+		if label, ok := t.sections[i]; ok {
+			sections[len(lines)] = label
+		}
+		if isBlankRow(row) {
+			continue
+		}
+		if selection != nil && t.selection[i] {
+			selection[len(lines)] = true
+		}
+		if params, ok := t.rowParams[i]; ok {
+			rowParams[len(lines)] = params
+		}
+		rs[len(lines)] = t.rs[i]
+		lines = append(lines, row)
+	}
+	t.lines, t.rs, t.rowParams = lines, rs, rowParams
+	if selection != nil {
+		t.selection = selection
+	}
+	if sections != nil {
+		t.sections = sections
+	}
+}
+
+// isBlankRow - check if all the cells of a row are blank
+func isBlankRow(row [][]string) bool {
+	for _, cell := range row {
+		if !isBlankCell(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBlankCell - check if all the lines of a cell are blank
+func isBlankCell(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyColumn - check if the header, footer and cells of a column are blank
+func (t *Table) isEmptyColumn(col int) bool {
+	if col < len(t.headers) && !isBlankCell(t.headers[col]) {
+		return false
+	}
+	if col < len(t.footers) && !isBlankCell(t.footers[col]) {
+		return false
+	}
+	for _, row := range t.lines {
+		if col < len(row) && !isBlankCell(row[col]) {
+			return false
+		}
+	}
+	return true
+}
+
+// dropColumns - remove the empty columns when SetDropEmptyColumns is on
+// The per column settings are moved along with the columns they apply to.
+func (t *Table) dropColumns() {
+	if !t.dropEmptyColumns {
+		return
+	}
+	keep := []int{}
+	for col := 0; col < len(t.cs); col++ {
+		if !t.isEmptyColumn(col) {
+			keep = append(keep, col)
+		}
+	}
+	if len(keep) == 0 || len(keep) == len(t.cs) {
+		return
+	}
+	t.moveColumns(keep)
+	if t.colSize > len(keep) {
+		t.colSize = len(keep)
+	}
+}
+
+// moveColumns - rebuild the columns from the old columns listed in keep
+// An index of -1 adds an empty column. The per column settings are moved
+// along with the columns they apply to.
+func (t *Table) moveColumns(keep []int) {
+	// index maps the old column index to the new one
+	index := make(map[int]int, len(keep))
+	for i, col := range keep {
+		if col >= 0 {
+			index[col] = i
+		}
+	}
+
+	t.headers = keepCells(t.headers, keep)
+	t.footers = keepCells(t.footers, keep)
+	for i, row := range t.lines {
+		t.lines[i] = keepCells(row, keep)
+	}
+	rowParams := make(map[int][]string, len(t.rowParams))
+	for i, params := range t.rowParams {
+		rowParams[i] = keepStrings(params, keep)
+	}
+	t.rowParams = rowParams
+	t.headerParams = keepStrings(t.headerParams, keep)
+	t.columnsParams = keepStrings(t.columnsParams, keep)
+	t.footerParams = keepStrings(t.footerParams, keep)
+	t.columnsAlign = keepInts(t.columnsAlign, keep)
+	t.headerColumnsAlign = keepInts(t.headerColumnsAlign, keep)
+	t.footerColumnsAlign = keepInts(t.footerColumnsAlign, keep)
+	t.columnsVAlign = keepInts(t.columnsVAlign, keep)
+	t.cs = remapInts(t.cs, index)
+	t.headerCs = remapInts(t.headerCs, index)
+	t.columnsMinWidth = remapInts(t.columnsMinWidth, index)
+	t.columnsMaxWidth = remapInts(t.columnsMaxWidth, index)
+	t.columnsDecimals = remapInts(t.columnsDecimals, index)
+
+	columnsType := make(map[int]ColumnType)
+	for col, v := range t.columnsType {
+		if i, ok := index[col]; ok {
+			columnsType[i] = v
+		}
+	}
+	t.columnsType = columnsType
+
+	columnSeps := make(map[int]string)
+	for col, v := range t.columnSeps {
+		if i, ok := index[col]; ok {
+			columnSeps[i] = v
+		}
+	}
+	t.columnSeps = columnSeps
+
+	t.columnHeavy = remapBools(t.columnHeavy, index)
+	t.columnsUnitSplit = remapBools(t.columnsUnitSplit, index)
+	t.columnsHumanize = remapBools(t.columnsHumanize, index)
+	t.columnsBytes = remapBools(t.columnsBytes, index)
+	t.columnsDuration = remapBools(t.columnsDuration, index)
+	t.columnsToAutoMergeCells = remapBools(t.columnsToAutoMergeCells, index)
+}
+
+// addSelectionColumn - prepend the column of SetSelectionColumn
+func (t *Table) addSelectionColumn() {
+	if t.selection == nil || len(t.cs) == 0 {
+		return
+	}
+	keep := []int{-1}
+	for col := 0; col < len(t.cs); col++ {
+		keep = append(keep, col)
+	}
+	t.moveColumns(keep)
+	t.colSize++
+
+	width := t.width(t.selectionGlyphs[0])
+	if w := t.width(t.selectionGlyphs[1]); w > width {
+		width = w
+	}
+	for i, row := range t.lines {
+		glyph := ConditionString(t.selection[i], t.selectionGlyphs[0], t.selectionGlyphs[1])
+		row[0] = []string{glyph}
+	}
+	t.cs[0] = width
+	for len(t.columnsAlign) < len(t.cs) {
+		t.columnsAlign = append(t.columnsAlign, t.align)
+	}
+	t.columnsAlign[0] = ALIGN_CENTER
+}
+
+// addGutterColumns - insert the empty columns of SetGutterColumns
+func (t *Table) addGutterColumns() {
+	if len(t.gutters) == 0 || len(t.cs) == 0 {
+		return
+	}
+	keep := []int{}
+	for col := 0; col < len(t.cs); {
+		if t.gutters[len(keep)] {
+			keep = append(keep, -1)
+			continue
+		}
+		keep = append(keep, col)
+		col++
+	}
+	if len(keep) == len(t.cs) {
+		return
+	}
+	t.colSize += len(keep) - len(t.cs)
+	t.moveColumns(keep)
+	for i, col := range keep {
+		if col < 0 {
+			t.cs[i] = 1
+			// Empty footer cells would erase the separators around them
+			if i < len(t.footers) {
+				t.footers[i] = []string{SPACE}
+			}
+		}
+	}
+}
+
+// keepCells - select the cells at the given indexes
+func keepCells(cells [][]string, keep []int) [][]string {
+	if len(cells) == 0 {
+		return cells
+	}
+	kept := make([][]string, 0, len(keep))
+	for _, col := range keep {
+		if col < 0 {
+			kept = append(kept, []string{""})
+		} else if col < len(cells) {
+			kept = append(kept, cells[col])
 		}
-		// Next line
-		fmt.Fprint(t.out, t.newLine)
 	}
+	return kept
+}
 
-	hasPrinted := false
+// keepStrings - select the values at the given indexes
+func keepStrings(values []string, keep []int) []string {
+	if len(values) == 0 {
+		return values
+	}
+	kept := make([]string, 0, len(keep))
+	for _, col := range keep {
+		if col < 0 {
+			kept = append(kept, "")
+		} else if col < len(values) {
+			kept = append(kept, values[col])
+		}
+	}
+	return kept
+}
 
-	for i := 0; i <= end; i++ {
-		v := t.cs[i]
-		pad := t.syms[symEW]
-		center := t.syms[symNEW]
-		length := len(t.footers[i][0])
+// keepInts - select the values at the given indexes
+func keepInts(values []int, keep []int) []int {
+	if len(values) == 0 {
+		return values
+	}
+	kept := make([]int, 0, len(keep))
+	for _, col := range keep {
+		if col < 0 {
+			kept = append(kept, ALIGN_DEFAULT)
+		} else if col < len(values) {
+			kept = append(kept, values[col])
+		}
+	}
+	return kept
+}
 
-		if length > 0 {
-			hasPrinted = true
+// remapInts - move the values of a per column map to their new index
+func remapInts(m map[int]int, index map[int]int) map[int]int {
+	moved := make(map[int]int, len(m))
+	for col, v := range m {
+		if i, ok := index[col]; ok {
+			moved[i] = v
 		}
+	}
+	return moved
+}
 
-		// Set center to be space if length is 0
-		if length == 0 && !t.borders.Right {
-			center = SPACE
+// remapBools - move the flags of a per column map to their new index
+func remapBools(m map[int]bool, index map[int]int) map[int]bool {
+	moved := make(map[int]bool, len(m))
+	for col, v := range m {
+		if i, ok := index[col]; ok {
+			moved[i] = v
 		}
+	}
+	return moved
+}
 
-		// Print first junction
-		if i == 0 {
-			if length > 0 && !t.borders.Left {
-				center = t.syms[symEW]
-			} else if center != SPACE {
-				center = t.syms[symNE]
+// splitUnit - split a cell line into its value and its unit
+func splitUnit(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if i := strings.Index(line, SPACE); i >= 0 {
+		return line[:i], strings.TrimSpace(line[i+1:])
+	}
+	return line, ""
+}
+
+// unitWidths - widest value and unit of a unit split column
+func (t *Table) unitWidths(col int) (int, int) {
+	num, unit := 0, 0
+	for _, row := range t.lines {
+		if col >= len(row) {
+			continue
+		}
+		for _, line := range row[col] {
+			n, u := splitUnit(line)
+			if w := t.width(n); w > num {
+				num = w
+			}
+			if w := t.width(u); w > unit {
+				unit = w
 			}
-			fmt.Fprint(t.out, center)
 		}
+	}
+	return num, unit
+}
 
-		// Pad With space of length is 0
-		if length == 0 {
-			pad = SPACE
+// fillUnitSplit - line up the values and units of the unit split columns
+func (t *Table) fillUnitSplit() {
+	for col := range t.columnsUnitSplit {
+		if col < 0 || col >= len(t.cs) {
+			continue
 		}
-		// Ignore left space as it has printed before
-		if hasPrinted || t.borders.Left {
-			pad = t.syms[symEW]
-			center = t.syms[symNEW]
+		num, unit := t.unitWidths(col)
+		if unit == 0 {
+			continue
 		}
-
-		// Change Center end position
-		if center != SPACE {
-			if i == end {
-				if t.borders.Right {
-					center = t.syms[symNW]
-				} else {
-					center = t.syms[symEW]
-				}
+		for _, row := range t.lines {
+			if col >= len(row) {
+				continue
 			}
-		}
-
-		// Change Center start position
-		if center == SPACE {
-			if i < end && len(t.footers[i+1][0]) != 0 {
-				if !t.borders.Left {
-					center = t.syms[symEW]
-				} else {
-					center = t.syms[symNEW]
+			// The lines are padded in a new cell, the stored one is kept
+			row[col] = append([]string(nil), row[col]...)
+			for i, line := range row[col] {
+				if strings.TrimSpace(line) == "" {
+					continue
 				}
+				n, u := splitUnit(line)
+				row[col][i] = t.pad(ALIGN_RIGHT)(n, SPACE, num) + SPACE + t.pad(ALIGN_LEFT)(u, SPACE, unit)
 			}
 		}
-
-		// Print the footer
-		fmt.Fprintf(t.out, "%s%s%s%s",
-			pad,
-			strings.Repeat(string(pad), v),
-			pad,
-			center)
-
+		if w := num + 1 + unit; w > t.cs[col] {
+			t.cs[col] = w
+		}
 	}
-
-	fmt.Fprint(t.out, t.newLine)
 }
 
-// Print caption text
-func (t *Table) printCaption() {
-	width := t.getTableWidth()
-	paragraph, _ := WrapString(t.captionText, width)
-	for linecount := 0; linecount < len(paragraph); linecount++ {
-		fmt.Fprintln(t.out, paragraph[linecount])
+// fillGrid - give all the columns the width of the widest one in grid mode
+func (t *Table) fillGrid() {
+	if !t.grid {
+		return
+	}
+	max := 0
+	for _, v := range t.cs {
+		if v > max {
+			max = v
+		}
+	}
+	for i := range t.cs {
+		t.cs[i] = max
 	}
 }
 
-// Calculate the total number of characters in a row
-func (t *Table) getTableWidth() int {
-	var chars int
-	for _, v := range t.cs {
-		chars += v
+// elideColumns - replace the columns beyond the strict maximum width
+// with a column of truncation indicators
+func (t *Table) elideColumns() {
+	if !t.elide || t.maxWidthStrict <= 0 || len(t.cs) < 2 ||
+		t.getTableWidth()+t.indent <= t.maxWidthStrict {
+		return
+	}
+	marker := t.width(t.truncateIndicator)
+	cell := 2*t.cellSpacing + 1
+
+	// Width of the left border, the marker column and the right border
+	width := t.indent + 1 + cell + marker
+	keep := []int{0}
+	width += t.cs[0] + cell
+	for col := 1; col < len(t.cs)-1; col++ {
+		w := t.cs[col] + cell + t.width(t.columnSeparator(col)) - 1
+		if width+w > t.maxWidthStrict {
+			break
+		}
+		width += w
+		keep = append(keep, col)
+	}
+	n := len(keep)
+	t.moveColumns(append(keep, -1))
+	if t.colSize > n+1 {
+		t.colSize = n + 1
 	}
 
-	// Add chars, spaces, seperators to calculate the total width of the table.
-	// ncols := t.colSize
-	// spaces := ncols * 2
-	// seps := ncols + 1
+	rows := [][][]string{t.headers, t.footers}
+	for _, row := range append(rows, t.lines...) {
+		if len(row) == n+1 {
+			row[n] = []string{t.truncateIndicator}
+		}
+	}
+	t.cs[n] = marker
+}
 
-	return (chars + (3 * t.colSize) + 2)
+// fillMinWidth - widen the last column up to the minimal table width
+func (t *Table) fillMinWidth() {
+	if len(t.cs) == 0 {
+		return
+	}
+	if w := t.getTableWidth(); w < t.minWidth {
+		t.cs[len(t.cs)-1] += t.minWidth - w
+	}
 }
 
 // printRows - print all the rows
 func (t *Table) printRows() {
-	for i, lines := range t.lines {
+	for i, lines := range t.lines[:t.shownRows()] {
+		if t.hasSection(i) {
+			t.printSectionLine(i)
+		}
 		t.printRow(lines, i)
+		t.notifyRowRendered(i)
+	}
+	if t.spanningText() != "" {
+		t.printSpanningRow()
+		if t.rowLine && t.hasBottomLine() {
+			t.printBottomLine()
+		}
+	} else if len(t.lines) == 0 && t.rowLine && t.hasBottomLine() && !t.closedByTopFooter() {
+		t.printBottomLine()
 	}
 }
 
-// fillAlignment - fill the alignment
-func (t *Table) fillAlignment(num int) {
-	if len(t.columnsAlign) < num {
-		t.columnsAlign = make([]int, num)
-		for i := range t.columnsAlign {
-			t.columnsAlign[i] = t.align
-		}
+// spanningText - text of the row spanning the table after the shown rows
+// It counts the rows omitted by SetMaxRows, or is the message of
+// SetEmptyMessage without rows. It is empty when there is no such row.
+func (t *Table) spanningText() string {
+	if t.shownRows() < len(t.lines) {
+		hidden := len(t.lines) - t.shownRows()
+		return fmt.Sprintf("%s %s more %s", ELLIPSIS, formatThousands(hidden),
+			ConditionString(hidden == 1, "row", "rows"))
+	}
+	if len(t.lines) == 0 && len(t.cs) > 0 {
+		return t.emptyMessage
 	}
+	return ""
+}
+
+// fillHiddenRows - widen the last column until the spanning row text fits
+func (t *Table) fillHiddenRows() {
+	text := t.spanningText()
+	if len(t.cs) == 0 || text == "" {
+		return
+	}
+	// The text is surrounded by spaces inside the outer borders
+	if w := t.width(text) + 4; w > t.getTableWidth() {
+		t.cs[len(t.cs)-1] += w - t.getTableWidth()
+	}
+}
+
+// shownRows - number of rows printed under the limit of SetMaxRows
+func (t *Table) shownRows() int {
+	if t.maxRows > 0 && t.maxRows < len(t.lines) {
+		return t.maxRows
+	}
+	return len(t.lines)
+}
+
+// printSpanningRow - print the row spanning the table with the omitted rows
+// count or the empty message
+func (t *Table) printSpanningRow() {
+	// Everything but the outer borders
+	left := ConditionString(t.borders.Left, t.syms[symNS], SPACE)
+	right := ConditionString(t.borders.Right, t.syms[symNS], SPACE)
+	width := t.getTableWidth() - t.width(left) - t.width(right)
+	fmt.Fprint(t.out, left,
+		t.pad(ALIGN_CENTER)(t.spanningText(), SPACE, width),
+		right,
+		t.newLine)
+}
+
+// richLines - the lines of a cell with the colors given to Rich
+func (t *Table) richLines(rowIdx, col int, lines []string) []string {
+	params := t.rowParams[rowIdx]
+	if col >= len(params) || params[col] == "" {
+		return lines
+	}
+	colored := make([]string, len(lines))
+	for i, line := range lines {
+		colored[i] = t.colorize(line, params[col])
+	}
+	return colored
 }
 
 // Print Row Information
@@ -856,6 +3062,7 @@ func (t *Table) printRow(columns [][]string, rowIdx int) {
 	// Get Maximum Height
 	max := t.rs[rowIdx]
 	total := len(columns)
+	space := strings.Repeat(SPACE, t.cellSpacing)
 
 	// TODO Fix uneven col size
 	// if total < t.colSize {
@@ -873,15 +3080,19 @@ func (t *Table) printRow(columns [][]string, rowIdx int) {
 	if len(t.columnsParams) > 0 {
 		is_esc_seq = true
 	}
-	t.fillAlignment(total)
+
+	// Identical neighbours are printed as one cell
+	var spanned map[int]bool
+	if !t.noWhiteSpace {
+		spanned = t.spannedBoundaries(rowIdx)
+	}
 
 	for i, line := range columns {
+		line = t.richLines(rowIdx, i, line)
 		length := len(line)
 		pad := max - length
 		pads = append(pads, pad)
-		for n := 0; n < pad; n++ {
-			columns[i] = append(columns[i], "  ")
-		}
+		columns[i] = t.padHeight(i, line, max)
 	}
 	//fmt.Println(max, "\n")
 	for x := 0; x < max; x++ {
@@ -889,58 +3100,259 @@ func (t *Table) printRow(columns [][]string, rowIdx int) {
 
 			// Check if border is set
 			if !t.noWhiteSpace {
-				fmt.Fprint(t.out, ConditionString((!t.borders.Left && y == 0), SPACE, t.syms[symNS]))
-				fmt.Fprintf(t.out, SPACE)
+				fmt.Fprint(t.out, ConditionString((!t.borders.Left && y == 0), SPACE, t.columnSeparator(y)))
+				fmt.Fprint(t.out, space)
 			}
 
 			str := columns[y][x]
 
 			// Embedding escape sequence with column value
 			if is_esc_seq {
-				str = format(str, t.columnsParams[y])
+				str = t.colorize(str, t.columnsParams[y])
+			}
+
+			// A spanning cell takes the width of the columns and separators
+			first, width := y, t.cs[y]
+			for spanned[y] && y+1 < total {
+				y++
+				width += 2*t.cellSpacing + t.width(t.columnSeparator(y)) + t.cs[y]
 			}
 
 			// This would print alignment
 			// Default alignment  would use multiple configuration
-			switch t.columnsAlign[y] {
-			case ALIGN_CENTER: //
-				fmt.Fprintf(t.out, "%s", Pad(str, SPACE, t.cs[y]))
-			case ALIGN_RIGHT:
-				fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
-			case ALIGN_LEFT:
-				fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-			default:
-				if decimal.MatchString(strings.TrimSpace(str)) || percent.MatchString(strings.TrimSpace(str)) {
-					fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
-				} else {
-					fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-
-					// TODO Custom alignment per column
-					//if max == 1 || pads[y] > 0 {
-					//	fmt.Fprintf(t.out, "%s", Pad(str, SPACE, t.cs[y]))
-					//} else {
-					//	fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-					//}
-
-				}
-			}
-			if !t.noWhiteSpace {
-				fmt.Fprintf(t.out, SPACE)
+			align := t.cellAlignment(first, str)
+			if t.hugsRight(align) {
+				// The trailing space moves to the left of the cell
+				fmt.Fprintf(t.out, "%s", t.pad(align)(str, SPACE, width+t.cellSpacing))
+			} else if !t.noWhiteSpace {
+				fmt.Fprintf(t.out, "%s", t.pad(align)(str, SPACE, width))
+				fmt.Fprint(t.out, space)
 			} else {
+				fmt.Fprintf(t.out, "%s", t.pad(align)(str, SPACE, width))
 				fmt.Fprintf(t.out, t.tablePadding)
 			}
 		}
 		// Check if border is set
 		// Replace with space if not set
 		if !t.noWhiteSpace {
-			fmt.Fprint(t.out, ConditionString(t.borders.Left, t.syms[symNS], SPACE))
+			fmt.Fprint(t.out, ConditionString(t.borders.Right, t.syms[symNS], SPACE))
 		}
 		fmt.Fprint(t.out, t.newLine)
 	}
 
-	if t.rowLine {
-		t.printLine(false, rowIdx == len(t.lines)-1 && len(t.footers) == 0)
+	if t.rowLine && (rowIdx < len(t.lines)-1 || t.hasBottomLine()) && !t.hasSection(rowIdx+1) {
+		// The line after the last row closes the body and stays solid
+		style := t.rowLineStyle
+		if rowIdx == len(t.lines)-1 {
+			style = LINE_SOLID
+		}
+		t.printSpannedLine(false, rowIdx == len(t.lines)-1 && !t.hasBottomFooter(), style,
+			spanned, t.spannedBoundaries(rowIdx+1))
+	}
+}
+
+// spannedBoundaries - column boundaries crossed by a cell of the row
+// The key i is the boundary between the columns i and i+1. With
+// SetAutoMergeCellsHorizontal identical neighbours span their boundary and
+// the omitted rows count of SetMaxRows or the message of SetEmptyMessage
+// spans them all.
+func (t *Table) spannedBoundaries(rowIdx int) map[int]bool {
+	if rowIdx == t.shownRows() && t.spanningText() != "" {
+		all := make(map[int]bool)
+		for i := 0; i < len(t.cs)-1; i++ {
+			all[i] = true
+		}
+		return all
+	}
+	if !t.autoMergeHorizontal || rowIdx < 0 || rowIdx >= t.shownRows() {
+		return nil
+	}
+	row := t.lines[rowIdx]
+	spanned := make(map[int]bool)
+	for i := 0; i+1 < len(row); i++ {
+		if text := cellText(row[i]); text != "" && text == cellText(row[i+1]) {
+			spanned[i] = true
+		}
+	}
+	return spanned
+}
+
+// cellText - text of a cell, whatever its wrapping and padding
+func cellText(lines []string) string {
+	return strings.Join(strings.Fields(strings.Join(lines, SPACE)), SPACE)
+}
+
+// hugsRight - check if a body cell is printed against its right separator
+func (t *Table) hugsRight(align int) bool {
+	return t.alignedPadding && align == ALIGN_RIGHT && !t.noWhiteSpace
+}
+
+// cellAlignment - resolve the alignment of a body cell
+// Default alignment is resolved to the right for numbers and to the left otherwise
+func (t *Table) cellAlignment(col int, str string) int {
+	if t.grid {
+		return ALIGN_CENTER
+	}
+	if _, ok := t.columnsDecimals[col]; ok {
+		if _, ok := t.parseNumber(ansi.ReplaceAllLiteralString(str, "")); ok {
+			return ALIGN_RIGHT
+		}
+	}
+	align := t.align
+	if col < len(t.columnsAlign) {
+		align = t.columnsAlign[col]
+	}
+	if align != ALIGN_DEFAULT {
+		return align
+	}
+	switch t.columnsType[col] {
+	case TYPE_TEXT:
+		return ALIGN_LEFT
+	case TYPE_NUMBER:
+		return ALIGN_RIGHT
+	}
+	if t.columnsDuration[col] && duration.MatchString(strings.TrimSpace(str)) {
+		return ALIGN_RIGHT
+	}
+	str = t.normalizeNumber(strings.TrimSpace(str))
+	if t.negativeStyle != 0 {
+		str = ansi.ReplaceAllLiteralString(str, "")
+		if t.negativeStyle&NEGATIVE_PARENS != 0 && isParenNegative(str) {
+			return ALIGN_RIGHT
+		}
+	}
+	if decimal.MatchString(str) || percent.MatchString(str) ||
+		(t.columnsHumanize[col] && humanized.MatchString(str)) ||
+		(t.columnsBytes[col] && byteSize.MatchString(str)) {
+		return ALIGN_RIGHT
+	}
+	return ALIGN_LEFT
+}
+
+// normalizeNumber - write a number with the decimal separator of
+// SetDecimalSeparator using a dot, for the numeric checks
+// With a comma as decimal separator dots are taken as thousands separators.
+func (t *Table) normalizeNumber(str string) string {
+	if t.decimalSep == '.' {
+		return str
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == t.decimalSep:
+			return '.'
+		case r == '.' && t.decimalSep == ',':
+			return ','
+		}
+		return r
+	}, str)
+}
+
+// parseNumber - parse a number written with SetDecimalSeparator
+// The thousands separators are dropped, so 1.234,5 is read as 1234.5 with a
+// comma as decimal separator.
+func (t *Table) parseNumber(str string) (float64, bool) {
+	str = strings.TrimSpace(t.normalizeNumber(str))
+	if decimal.MatchString(str) {
+		str = strings.Replace(str, ",", "", -1)
+	}
+	return parseFloat(str)
+}
+
+// localizeNumber - write a number formatted by strconv with the decimal separator
+func (t *Table) localizeNumber(str string) string {
+	if t.decimalSep == '.' {
+		return str
+	}
+	return strings.Replace(str, ".", string(t.decimalSep), 1)
+}
+
+// isParenNegative - check for a negative number written as (123.45)
+func isParenNegative(str string) bool {
+	if len(str) < 3 || str[0] != '(' || str[len(str)-1] != ')' {
+		return false
+	}
+	return decimal.MatchString("-" + str[1:len(str)-1])
+}
+
+// formatNegative - apply the negative style to a numeric cell
+func (t *Table) formatNegative(str string) string {
+	if !strings.HasPrefix(str, "-") || !decimal.MatchString(t.normalizeNumber(str)) {
+		return str
+	}
+	if t.negativeStyle&NEGATIVE_PARENS != 0 {
+		str = "(" + str[1:] + ")"
+	}
+	if t.negativeStyle&NEGATIVE_RED != 0 {
+		str = t.colorize(str, Colors{FgRedColor})
+	}
+	return str
+}
+
+// escapeSeparators - prefix the column separators of a cell with a backslash
+func (t *Table) escapeSeparators(str string) string {
+	sep := t.syms[symNS]
+	if strings.TrimSpace(sep) == "" || !strings.Contains(str, sep) {
+		return str
+	}
+	return strings.Replace(str, sep, `\`+sep, -1)
+}
+
+// formatCell - apply the per column formatting to a body cell
+func (t *Table) formatCell(str string, colKey int) string {
+	if str == "" {
+		return t.emptyPlaceholder
+	}
+	if places, ok := t.columnsDecimals[colKey]; ok {
+		if f, ok := t.parseNumber(str); ok {
+			str = t.localizeNumber(strconv.FormatFloat(f, 'f', places, 64))
+		}
+	}
+	if t.columnsHumanize[colKey] {
+		if f, ok := t.parseNumber(str); ok {
+			str = t.localizeNumber(humanizeNumber(f, t.humanizePrecision))
+		}
+	}
+	if t.columnsBytes[colKey] {
+		if f, ok := t.parseNumber(str); ok {
+			str = t.localizeNumber(humanizeBytes(f, t.humanizePrecision, t.humanizeBytesSI))
+		}
+	}
+	if t.columnsDuration[colKey] {
+		if d, err := time.ParseDuration(strings.TrimSpace(str)); err == nil {
+			str = HumanizeDuration(d)
+		}
+	}
+	if t.negativeStyle != 0 {
+		str = t.formatNegative(str)
+	}
+	return str
+}
+
+// padHeight - pad the lines of a cell up to the row height
+// The blank lines are placed according to the vertical alignment of the column
+func (t *Table) padHeight(col int, lines []string, height int) []string {
+	gap := height - len(lines)
+	if gap <= 0 {
+		return lines
+	}
+	top := 0
+	if col < len(t.columnsVAlign) {
+		switch t.columnsVAlign[col] {
+		case VALIGN_MIDDLE:
+			top = gap / 2
+		case VALIGN_BOTTOM:
+			top = gap
+		}
+	}
+	padded := make([]string, 0, height)
+	for n := 0; n < top; n++ {
+		padded = append(padded, "")
 	}
+	padded = append(padded, lines...)
+	for len(padded) < height {
+		padded = append(padded, "")
+	}
+	return padded
 }
 
 // Print the rows of the table and merge the cells that are identical
@@ -948,19 +3360,32 @@ func (t *Table) printRowsMergeCells() {
 	var previousLine []string
 	var displayCellBorder []bool
 	var tmpWriter bytes.Buffer
-	for i, lines := range t.lines {
+	for i, lines := range t.lines[:t.shownRows()] {
 		// We store the display of the current line in a tmp writer, as we need to know which border needs to be print above
+		// Cells are not merged across a section line
+		if t.hasSection(i) {
+			previousLine = nil
+		}
 		previousLine, displayCellBorder = t.printRowMergeCells(&tmpWriter, lines, i, previousLine)
-		if i > 0 { //We don't need to print borders above first line
+		if t.hasSection(i) {
+			t.printSectionLine(i)
+		} else if i > 0 { //We don't need to print borders above first line
 			if t.rowLine {
 				t.printLineOptionalCellSeparators(true, displayCellBorder)
 			}
 		}
 		tmpWriter.WriteTo(t.out)
+		t.notifyRowRendered(i)
+	}
+	if t.spanningText() != "" {
+		if t.rowLine && t.shownRows() > 0 {
+			t.printSpannedLine(false, false, LINE_SOLID, nil, t.spannedBoundaries(t.shownRows()))
+		}
+		t.printSpanningRow()
 	}
 	//Print the end of the table
-	if t.rowLine {
-		t.printLine(false, true)
+	if t.rowLine && t.hasBottomLine() && !t.closedByTopFooter() {
+		t.printBottomLine()
 	}
 }
 
@@ -970,6 +3395,7 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 	// Get Maximum Height
 	max := t.rs[rowIdx]
 	total := len(columns)
+	space := strings.Repeat(SPACE, t.cellSpacing)
 
 	// Pad Each Height
 	pads := []int{}
@@ -980,29 +3406,27 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 		isEscSeq = true
 	}
 	for i, line := range columns {
+		line = t.richLines(rowIdx, i, line)
 		length := len(line)
 		pad := max - length
 		pads = append(pads, pad)
-		for n := 0; n < pad; n++ {
-			columns[i] = append(columns[i], "  ")
-		}
+		columns[i] = t.padHeight(i, line, max)
 	}
 
 	var displayCellBorder []bool
-	t.fillAlignment(total)
 	for x := 0; x < max; x++ {
 		for y := 0; y < total; y++ {
 
 			// Check if border is set
-			fmt.Fprint(writer, ConditionString((!t.borders.Left && y == 0), SPACE, t.syms[symNS]))
+			fmt.Fprint(writer, ConditionString((!t.borders.Left && y == 0), SPACE, t.columnSeparator(y)))
 
-			fmt.Fprintf(writer, SPACE)
+			fmt.Fprint(writer, space)
 
 			str := columns[y][x]
 
 			// Embedding escape sequence with column value
 			if isEscSeq {
-				str = format(str, t.columnsParams[y])
+				str = t.colorize(str, t.columnsParams[y])
 			}
 
 			if t.autoMergeCells {
@@ -1030,25 +3454,17 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 
 			// This would print alignment
 			// Default alignment  would use multiple configuration
-			switch t.columnsAlign[y] {
-			case ALIGN_CENTER: //
-				fmt.Fprintf(writer, "%s", Pad(str, SPACE, t.cs[y]))
-			case ALIGN_RIGHT:
-				fmt.Fprintf(writer, "%s", PadLeft(str, SPACE, t.cs[y]))
-			case ALIGN_LEFT:
-				fmt.Fprintf(writer, "%s", PadRight(str, SPACE, t.cs[y]))
-			default:
-				if decimal.MatchString(strings.TrimSpace(str)) || percent.MatchString(strings.TrimSpace(str)) {
-					fmt.Fprintf(writer, "%s", PadLeft(str, SPACE, t.cs[y]))
-				} else {
-					fmt.Fprintf(writer, "%s", PadRight(str, SPACE, t.cs[y]))
-				}
+			align := t.cellAlignment(y, str)
+			if t.hugsRight(align) {
+				fmt.Fprintf(writer, "%s", t.pad(align)(str, SPACE, t.cs[y]+t.cellSpacing))
+			} else {
+				fmt.Fprintf(writer, "%s", t.pad(align)(str, SPACE, t.cs[y]))
+				fmt.Fprint(writer, space)
 			}
-			fmt.Fprintf(writer, SPACE)
 		}
 		// Check if border is set
 		// Replace with space if not set
-		fmt.Fprint(writer, ConditionString(t.borders.Left, t.syms[symNS], SPACE))
+		fmt.Fprint(writer, ConditionString(t.borders.Right, t.syms[symNS], SPACE))
 		fmt.Fprint(writer, t.newLine)
 	}
 
@@ -1061,43 +3477,135 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 	return previousLine, displayCellBorder
 }
 
+// wrapString - wrap a paragraph according to the wrap mode
+func (t *Table) wrapString(s string, lim int) ([]string, int) {
+	if t.wrapFunc != nil {
+		lines := t.wrapFunc(s, lim)
+		width := 0
+		for _, line := range lines {
+			if w := t.width(line); w > width {
+				width = w
+			}
+		}
+		return lines, width
+	}
+	if t.wrapMode == WRAP_BALANCED {
+		return wrapBalancedWidth(s, lim, t.width)
+	}
+	return WrapStringWidth(s, lim, t.width)
+}
+
 // parseDimension - parse table dimensions
 func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
+	if t.cellTransform != nil {
+		str = t.cellTransform(rowKey, colKey, str)
+	}
+	if rowKey >= 0 {
+		str = t.formatCell(str, colKey)
+	}
+	mW := t.mW
+	max, clamp := t.columnsMaxWidth[colKey]
+	if clamp && max < mW {
+		mW = max
+	}
+	if w, ok := t.headerCs[colKey]; ok && rowKey == headerRowIdx {
+		mW = w
+	}
+	raw, maxWidth := t.layoutCell(str, mW, rowKey >= 0)
+	if clamp && maxWidth > max {
+		raw, maxWidth = t.clampLines(raw, max)
+	}
+	t.storeDimension(colKey, rowKey, maxWidth, len(raw))
+	//fmt.Printf("Raw %+v %d\n", raw, len(raw))
+	return raw
+}
+
+// clampLines - break the lines wider than max, splitting their long words
+// The URLs are kept whole with SetKeepURLsIntact, even when wider than max.
+func (t *Table) clampLines(lines []string, max int) ([]string, int) {
+	var clamped []string
+	width := 0
+	for _, line := range lines {
+		parts := []string{line}
+		if t.width(line) > max {
+			parts, _ = wrapBreakWordsWidth(line, max, t.width, t.keepURLs)
+		}
+		for _, part := range parts {
+			if w := t.width(part); w > width {
+				width = w
+			}
+		}
+		clamped = append(clamped, parts...)
+	}
+	return clamped, width
+}
+
+// MeasureCell Get the lines, width and height of s in a column of the given width
+// The text is escaped, truncated or wrapped and cut to the maximum row height
+// like a body cell, without the formatting settings of any column. The
+// width is a maximum, w may be smaller or larger for words that do not fit.
+func (t *Table) MeasureCell(s string, width int) (lines []string, w, h int) {
+	lines, w = t.layoutCell(s, width, true)
+	return lines, w, len(lines)
+}
+
+// layoutCell - split a cell into lines according to the maximum width mW
+// It returns the lines and their maximum width. The truncation and the
+// maximum row height only apply to body cells.
+func (t *Table) layoutCell(str string, mW int, body bool) ([]string, int) {
 	var (
 		raw      []string
 		maxWidth int
 	)
 
+	if t.escapeSeparator {
+		str = t.escapeSeparators(str)
+	}
+
 	raw = getLines(str)
 	maxWidth = 0
 	for _, line := range raw {
-		if w := DisplayWidth(line); w > maxWidth {
+		if w := t.width(line); w > maxWidth {
 			maxWidth = w
 		}
 	}
 
-	// If wrapping, ensure that all paragraphs in the cell fit in the
-	// specified width.
-	if t.autoWrap {
+	// If truncating, cut the lines of body cells that exceed the
+	// specified width instead of wrapping them.
+	if t.autoTruncate && body {
+		if maxWidth > mW {
+			maxWidth = mW
+		}
+		for i, line := range raw {
+			raw[i] = t.truncate(line, maxWidth, t.truncateIndicator)
+		}
+	} else if t.autoWrap {
+		// If wrapping, ensure that all paragraphs in the cell fit in the
+		// specified width.
 		// If there's a maximum allowed width for wrapping, use that.
-		if maxWidth > t.mW {
-			maxWidth = t.mW
+		if maxWidth > mW {
+			maxWidth = mW
 		}
 
 		// In the process of doing so, we need to recompute maxWidth. This
 		// is because perhaps a word in the cell is longer than the
 		// allowed maximum width in t.mW.
 		newMaxWidth := maxWidth
+		if t.wrapMode == WRAP_BALANCED {
+			// Balanced lines may all be narrower than the limit.
+			newMaxWidth = 0
+		}
 		newRaw := make([]string, 0, len(raw))
 
 		if t.reflowText {
-			// Make a single paragraph of everything.
-			raw = []string{strings.Join(raw, " ")}
+			// Make a single paragraph of each block of lines, blank lines
+			// separating the paragraphs.
+			raw = getParagraphs(raw)
 		}
 		for i, para := range raw {
-			paraLines, _ := WrapString(para, maxWidth)
+			paraLines, _ := t.wrapString(para, maxWidth)
 			for _, line := range paraLines {
-				if w := DisplayWidth(line); w > newMaxWidth {
+				if w := t.width(line); w > newMaxWidth {
 					newMaxWidth = w
 				}
 			}
@@ -1110,19 +3618,51 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
 		maxWidth = newMaxWidth
 	}
 
+	// Cut body cells to the maximum row height.
+	if t.maxRowHeight > 0 && body && len(raw) > t.maxRowHeight {
+		raw = raw[:t.maxRowHeight]
+		if t.truncateIndicator != "" {
+			raw[len(raw)-1] = t.truncateIndicator
+		}
+		maxWidth = 0
+		for _, line := range raw {
+			if w := t.width(line); w > maxWidth {
+				maxWidth = w
+			}
+		}
+	}
+
+	return raw, maxWidth
+}
+
+// parseLines - parse the dimensions of verbatim cell lines
+func (t *Table) parseLines(lines []string, colKey, rowKey int) []string {
+	raw := append([]string(nil), lines...)
+	if len(raw) == 0 {
+		raw = []string{""}
+	}
+	maxWidth := 0
+	for _, line := range raw {
+		if w := t.width(line); w > maxWidth {
+			maxWidth = w
+		}
+	}
+	t.storeDimension(colKey, rowKey, maxWidth, len(raw))
+	return raw
+}
+
+// storeDimension - remember the width of a column and the height of a row
+func (t *Table) storeDimension(colKey, rowKey, width, height int) {
 	// Store the new known maximum width.
 	v, ok := t.cs[colKey]
-	if !ok || v < maxWidth || v == 0 {
-		t.cs[colKey] = maxWidth
+	if !ok || v < width || v == 0 {
+		t.cs[colKey] = width
 	}
 
 	// Remember the number of lines for the row printer.
-	h := len(raw)
 	v, ok = t.rs[rowKey]
 
-	if !ok || v < h || v == 0 {
-		t.rs[rowKey] = h
+	if !ok || v < height || v == 0 {
+		t.rs[rowKey] = height
 	}
-	//fmt.Printf("Raw %+v %d\n", raw, len(raw))
-	return raw
 }
@@ -9,12 +9,16 @@ package tablewriter
 
 import (
 	"bytes"
+	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/mattn/go-runewidth"
 )
 
 func checkEqual(t *testing.T, got, want interface{}, msgs ...interface{}) {
@@ -246,6 +250,32 @@ func TestNumLines(t *testing.T) {
 	checkEqual(t, table.NumLines(), len(data), "Number of lines failed")
 }
 
+func TestAppendRow(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	checkEqual(t, table.AppendRow([]string{"a", "b"}), 0)
+	checkEqual(t, table.AppendRow([]string{"c", "d"}), 1)
+	table.ClearRows()
+	checkEqual(t, table.AppendRow([]string{"e", "f"}), 0)
+}
+
+func TestAppendValues(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.AppendValues("A", "The Good")
+	table.Append([]string{"B", "The Very Bad"})
+	table.Render()
+
+	want := `+------+--------------+
+| NAME |     SIGN     |
++------+--------------+
+| A    | The Good     |
+| B    | The Very Bad |
++------+--------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
 func TestCSVInfo(t *testing.T) {
 	buf := &bytes.Buffer{}
 	table, err := NewCSV(buf, "testdata/test_info.csv", true)
@@ -295,6 +325,126 @@ func TestCSVSeparator(t *testing.T) {
 	checkEqual(t, buf.String(), want, "CSV info failed")
 }
 
+func TestFromCSVFile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table, err := FromCSVFile(buf, "testdata/test_semicolon.csv", true, ';')
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	table.Render()
+
+	want := `+-------+----------+-----+
+| NAME  |   CITY   | AGE |
++-------+----------+-----+
+| Ada   | London   |  36 |
+| Linus | Helsinki |  28 |
++-------+----------+-----+
+`
+	checkEqual(t, buf.String(), want, "CSV file with delimiter failed")
+
+	if _, err := FromCSVFile(buf, "testdata/missing.csv", true, ','); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	for _, bom := range []bool{false, true} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"Name", "City"})
+		table.SetFooter([]string{"Total", "2"})
+		table.SetColWidth(6)
+		table.AppendBulk([][]string{
+			{"Zoë", "São Paulo"},
+			{"Bob", "New York, NY"},
+		})
+		if err := table.RenderCSV(CSVWriteOptions{BOM: bom}); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "Name,City\nZoë,São Paulo\nBob,\"New York, NY\"\nTotal,2\n"
+		if bom {
+			want = "\ufeff" + want
+		}
+		checkEqual(t, buf.String(), want, ConditionString(bom, "with BOM", "without BOM"))
+	}
+
+	// The values are exported as given, not as wrapped and formatted
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Price"})
+	table.SetColumnDecimals(1, 2)
+	table.Append([]string{"green\ntea", "1.5"})
+	if err := table.RenderCSV(CSVWriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, buf.String(), "Item,Price\n\"green\ntea\",1.5\n")
+}
+
+func TestCSVInferAlignment(t *testing.T) {
+	file, err := os.Open("testdata/test_mixed.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	buf := &bytes.Buffer{}
+	table, err := NewCSVReaderWithOptions(buf, csv.NewReader(file), true, CSVOptions{InferAlignment: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+----+------+-------+-------+
+| ID | NAME | SCORE |  ZIP  |
++----+------+-------+-------+
+|  1 | Ada  |  12.5 | 02139 |
+|  2 | Bob  |     7 | n/a   |
+| 10 | Cy   |       | 10001 |
++----+------+-------+-------+
+`
+	checkEqual(t, buf.String(), want, "CSV alignment inference failed")
+
+	// The text columns keep the alignment of the table
+	records := [][]string{{"1", "Ada", "12.5", "02139"}, {"2", "Bob", "7", "n/a"}}
+	checkEqual(t, inferAlignment(records), []int{ALIGN_RIGHT, ALIGN_DEFAULT, ALIGN_RIGHT, ALIGN_DEFAULT})
+}
+
+func TestCSVSkipEmptyAndComments(t *testing.T) {
+	want := `+------+-----+
+| NAME | AGE |
++------+-----+
+| Ada  |  36 |
+| Cy   |  28 |
++------+-----+
+`
+	file, err := os.Open("testdata/test_comments.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	buf := &bytes.Buffer{}
+	fileReader := csv.NewReader(file)
+	table, err := NewCSVReaderWithOptions(buf, fileReader, true, CSVOptions{SkipEmpty: true, CommentPrefix: "#"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+	checkEqual(t, buf.String(), want, "CSV comments failed")
+	checkEqual(t, fileReader.Comment, rune(0), "the Comment of the reader was not restored")
+
+	csvReader := csv.NewReader(strings.NewReader("// exported users\nname,age\nAda,36\n// Bob,40\n , \nCy,28\n"))
+	csvReader.FieldsPerRecord = -1
+	buf.Reset()
+	table, err = NewCSVReaderWithOptions(buf, csvReader, true, CSVOptions{SkipEmpty: true, CommentPrefix: "//"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+	checkEqual(t, buf.String(), want, "CSV comments with a long prefix failed")
+}
+
 func TestNoBorder(t *testing.T) {
 	data := [][]string{
 		{"1/1/2014", "Domain name", "2233", "$10.98"},
@@ -1198,7 +1348,6 @@ func TestClearRows(t *testing.T) {
 	want = `+----------+-------------+-------+---------+
 |   DATE   | DESCRIPTION |  CV2  | AMOUNT  |
 +----------+-------------+-------+---------+
-+----------+-------------+-------+---------+
 |                          TOTAL | $145.93 |
 +----------+-------------+-------+---------+
 `
@@ -1356,6 +1505,58 @@ func TestSetColMinWidth(t *testing.T) {
 	checkEqual(t, buf.String(), want)
 }
 
+func TestSetColMaxWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	for col := 0; col < 3; col++ {
+		table.SetColMinWidth(col, 4)
+		table.SetColMaxWidth(col, 8)
+	}
+	table.SetHeader([]string{"a", "b", "c"})
+	table.Append([]string{"x", "medium", "a long sentence"})
+	table.Append([]string{"y", "z", "supercalifragilistic"})
+	table.Render()
+
+	want := `+------+--------+----------+
+|  A   |   B    |    C     |
++------+--------+----------+
+| x    | medium | a long   |
+|      |        | sentence |
+| y    | z      | supercal |
+|      |        | ifragili |
+|      |        | stic     |
++------+--------+----------+
+`
+	checkEqual(t, buf.String(), want)
+
+	if err := table.Err(); err != nil {
+		t.Fatal(err)
+	}
+	table.SetColMinWidth(0, 9)
+	if table.Err() == nil {
+		t.Error("no error for a minimal width above the maximal width")
+	}
+	table = NewWriter(&buf)
+	table.SetColMinWidth(0, 4)
+	table.SetColMaxWidth(0, 3)
+	if table.Err() == nil {
+		t.Error("no error for a maximal width below the minimal width")
+	}
+
+	// The URLs are not broken
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetColMaxWidth(0, 20)
+	table.Append([]string{"see https://example.com/a/very/long/path"})
+	table.Render()
+	want = `+--------------------------------------+
+| see                                  |
+| https://example.com/a/very/long/path |
++--------------------------------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
 func TestWrapString(t *testing.T) {
 	want := []string{"ああああああああああああああああああああああああ", "あああああああ"}
 	got, _ := WrapString("ああああああああああああああああああああああああ あああああああ", 55)
@@ -1458,6 +1659,191 @@ func TestTitle(t *testing.T) {
 	}
 }
 
+func TestHumanizeNumber(t *testing.T) {
+	ts := []struct {
+		n    float64
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{12.345, "12.3"},
+		{0.05, "0.1"},
+		{1000, "1K"},
+		{1500, "1.5K"},
+		{1500000, "1.5M"},
+		{-2340000000, "-2.3B"},
+		{999999, "1M"},
+		{7.2e12, "7.2T"},
+		{3e15, "3000T"},
+	}
+	for _, tt := range ts {
+		got := HumanizeNumber(tt.n)
+		if got != tt.want {
+			t.Errorf("want %q, bot got %q", tt.want, got)
+		}
+	}
+}
+
+func TestSetColumnHumanize(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Metric", "Count"})
+	table.SetColumnHumanize(1)
+	table.SetHumanizePrecision(2)
+	table.AppendBulk([][]string{
+		{"views", "1534000"},
+		{"clicks", "48250"},
+		{"errors", "n/a"},
+	})
+	table.Render()
+
+	want := `+--------+--------+
+| METRIC | COUNT  |
++--------+--------+
+| views  |  1.53M |
+| clicks | 48.25K |
+| errors | n/a    |
++--------+--------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// Only the humanized columns align the suffixed numbers to the right
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Screen", "Rate"})
+	table.AppendBulk([][]string{{"4K", "60"}, {"1080", "144"}})
+	table.Render()
+
+	want = `+--------+------+
+| SCREEN | RATE |
++--------+------+
+| 4K     |   60 |
+|   1080 |  144 |
++--------+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	ts := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1 KiB"},
+		{1536, "1.5 KiB"},
+		{1610612736, "1.5 GiB"},
+		{1048575, "1 MiB"},
+	}
+	for _, tt := range ts {
+		got := HumanizeBytes(tt.n)
+		if got != tt.want {
+			t.Errorf("want %q, bot got %q", tt.want, got)
+		}
+	}
+}
+
+func TestSetColumnHumanizeBytes(t *testing.T) {
+	for _, si := range []bool{false, true} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"Volume", "Used"})
+		table.SetColumnHumanizeBytes(1)
+		table.SetHumanizeBytesSI(si)
+		table.AppendBulk([][]string{
+			{"root", "21474836480"},
+			{"home", "1500000"},
+			{"tmp", "900"},
+		})
+		table.Render()
+
+		want := `+--------+---------+
+| VOLUME |  USED   |
++--------+---------+
+| root   |  20 GiB |
+| home   | 1.4 MiB |
+| tmp    |   900 B |
++--------+---------+
+`
+		if si {
+			want = `+--------+---------+
+| VOLUME |  USED   |
++--------+---------+
+| root   | 21.5 GB |
+| home   |  1.5 MB |
+| tmp    |   900 B |
++--------+---------+
+`
+		}
+		checkEqual(t, buf.String(), want, ConditionString(si, "SI units", "IEC units"))
+	}
+
+	// Without SetColumnHumanizeBytes a size is aligned like text
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Limit", "Hits"})
+	table.AppendBulk([][]string{{"5 MB", "3"}, {"unlimited", "12"}})
+	table.Render()
+
+	want := `+-----------+------+
+|   LIMIT   | HITS |
++-----------+------+
+| 5 MB      |    3 |
+| unlimited |   12 |
++-----------+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	ts := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{850 * time.Nanosecond, "850ns"},
+		{12345 * time.Nanosecond, "12µs"},
+		{450 * time.Millisecond, "450ms"},
+		{999700 * time.Microsecond, "1s"},
+		{12500 * time.Millisecond, "12.5s"},
+		{90 * time.Second, "1m30s"},
+		{time.Hour + 23*time.Minute + 40*time.Second, "1h24m"},
+		{2 * time.Hour, "2h"},
+		{52 * time.Hour, "2d4h"},
+		{-3 * time.Minute, "-3m"},
+	}
+	for _, tt := range ts {
+		got := HumanizeDuration(tt.d)
+		if got != tt.want {
+			t.Errorf("want %q, bot got %q", tt.want, got)
+		}
+	}
+}
+
+func TestSetColumnHumanizeDuration(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Service", "Uptime"})
+	table.SetColumnHumanizeDuration(1)
+	table.AppendBulk([][]string{
+		{"api", (26*time.Hour + 10*time.Minute).String()},
+		{"cache", "450ms"},
+		{"db", "down"},
+	})
+	table.Render()
+
+	want := `+---------+--------+
+| SERVICE | UPTIME |
++---------+--------+
+| api     |   1d2h |
+| cache   |  450ms |
+| db      | down   |
++---------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
 func TestKubeFormat(t *testing.T) {
 	data := [][]string{
 		{"1/1/2014", "jan_hosting", "2233", "$10.98"},
@@ -1493,6 +1879,38 @@ func TestKubeFormat(t *testing.T) {
 	checkEqual(t, buf.String(), want, "kube format rendering failed")
 }
 
+func TestSetColumnTSpacing(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"NAME", "READY", "AGE"})
+	table.SetColumnTSpacing(2)
+	table.Append([]string{"web-1", "1/1", "12"})
+	table.Append([]string{"db", "0/1", "3"})
+	table.Append([]string{"cache", "1/1", "the last restart was two days ago"})
+	table.Render()
+
+	want := `NAME   READY  AGE
+web-1  1/1    12
+db     0/1    3
+cache  1/1    the last restart was two days ago
+`
+	checkEqual(t, buf.String(), want)
+
+	// The width is that of the printed lines, without borders nor spacing
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"NAME", "READY"})
+	table.SetColumnTSpacing(2)
+	table.SetMaxTableWidthStrict(13)
+	table.Append([]string{"db", "0/1"})
+	table.Render()
+	if err := table.Err(); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, buf.String(), "NAME  READY\ndb    0/1\n")
+	checkEqual(t, table.Layout().Width, 11)
+}
+
 type testStringerType struct{}
 
 func (t testStringerType) String() string { return "testStringerType" }
@@ -1688,3 +2106,2504 @@ func TestStructs(t *testing.T) {
 		})
 	}
 }
+
+func TestHeaderColumnAlignment(t *testing.T) {
+	var (
+		buf    = &bytes.Buffer{}
+		table  = NewWriter(buf)
+		header = []string{"Name", "Count", "Note"}
+		data   = [][]string{
+			{"alpha", "1234567", "first item"},
+			{"b", "8", "second"},
+		}
+		want = `+-------+---------+------------+
+| NAME  |   COUNT |    NOTE    |
++-------+---------+------------+
+| alpha | 1234567 | first item |
+| b     |       8 | second     |
++-------+---------+------------+
+`
+	)
+	table.SetHeader(header)
+	table.SetHeaderColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT})
+	table.AppendBulk(data)
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestHeaderColumnAlignmentIndependent(t *testing.T) {
+	var (
+		buf   = &bytes.Buffer{}
+		table = NewWriter(buf)
+		want  = `+-------+------------+--------+
+| NAME  |       CITY | AMOUNT |
++-------+------------+--------+
+| Alice | Paris      |     10 |
+| Bob   | Copenhagen |    200 |
++-------+------------+--------+
+`
+	)
+	table.SetHeader([]string{"Name", "City", "Amount"})
+	table.SetHeaderAlignment(ALIGN_RIGHT)
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_LEFT, ALIGN_RIGHT})
+	table.SetHeaderColumnAlignment([]int{ALIGN_CENTER, ALIGN_DEFAULT, ALIGN_LEFT})
+	table.Append([]string{"Alice", "Paris", "10"})
+	table.Append([]string{"Bob", "Copenhagen", "200"})
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestHeaderFollowsColumnAlign(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Product", "Quantity", "Note"})
+	table.SetHeaderFollowsColumnAlign(true)
+	table.SetHeaderColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_DEFAULT, ALIGN_CENTER})
+	table.Append([]string{"apple", "3", "fresh"})
+	table.Append([]string{"pear", "1,250", "ripe"})
+	table.Render()
+
+	want := `+---------+----------+-------+
+| PRODUCT | QUANTITY | NOTE  |
++---------+----------+-------+
+| apple   |        3 | fresh |
+| pear    |    1,250 | ripe  |
++---------+----------+-------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Total"})
+	table.SetHeaderFollowsColumnAlign(true)
+	table.Append([]string{"a", "12345678"})
+	table.Render()
+
+	want = `+----+----------+
+| ID |    TOTAL |
++----+----------+
+| a  | 12345678 |
++----+----------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestFooterColumnAlignment(t *testing.T) {
+	var (
+		buf    = &bytes.Buffer{}
+		table  = NewWriter(buf)
+		header = []string{"Item", "Amount", "Tax"}
+		data   = [][]string{
+			{"apples", "1,200.00", "12.00"},
+			{"pears", "300.00", "3.00"},
+		}
+		footer = []string{"Total", "1,500", "15"}
+		want   = `+--------+----------+-------+
+|  ITEM  |  AMOUNT  |  TAX  |
++--------+----------+-------+
+| apples | 1,200.00 | 12.00 |
+| pears  |   300.00 |  3.00 |
++--------+----------+-------+
+| TOTAL  |    1,500 |  15   |
++--------+----------+-------+
+`
+	)
+	table.SetHeader(header)
+	table.SetFooter(footer)
+	table.SetFooterColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT})
+	table.AppendBulk(data)
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSparkline(t *testing.T) {
+	checkEqual(t, Sparkline(nil), "")
+	checkEqual(t, Sparkline([]float64{1, 2, 3, 4, 5, 6, 7, 8}), "▁▂▃▄▅▆▇█")
+	checkEqual(t, Sparkline([]float64{0, 10, 5}), "▁█▄")
+	checkEqual(t, Sparkline([]float64{3, 3, 3}), "▅▅▅")
+
+	if runewidth.IsEastAsian() {
+		t.Skip("block elements are ambiguous width in East Asian locales")
+	}
+	var (
+		buf  = &bytes.Buffer{}
+		want = `+-------+----------+
+| HOST  |  TREND   |
++-------+----------+
+| web-1 | ▁▂▃▄▅▆▇█ |
+| db    | █▁█      |
++-------+----------+
+`
+	)
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Host", "Trend"})
+	table.Append([]string{"web-1", Sparkline([]float64{1, 2, 3, 4, 5, 6, 7, 8})})
+	table.Append([]string{"db", Sparkline([]float64{9, 1, 9})})
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestProgressBar(t *testing.T) {
+	checkEqual(t, ProgressBar(0.5, 8), "[████░░░░] 50%")
+	checkEqual(t, ProgressBar(0, 4), "[░░░░] 0%")
+	checkEqual(t, ProgressBar(1, 4), "[████] 100%")
+	checkEqual(t, ProgressBar(-0.3, 4), "[░░░░] 0%")
+	checkEqual(t, ProgressBar(1.7, 4), "[████] 100%")
+	checkEqual(t, ProgressBar(0.25, 10), "[███░░░░░░░] 25%")
+	checkEqual(t, ProgressBar(0.5, 0), "[] 50%")
+}
+
+func TestColumnDecimals(t *testing.T) {
+	var (
+		buf    = &bytes.Buffer{}
+		table  = NewWriter(buf)
+		header = []string{"Name", "Price"}
+		data   = [][]string{
+			{"tea", "3.5"},
+			{"coffee", "12"},
+			{"cake", "1.005e1"},
+			{"water", "n/a"},
+		}
+		want = `+--------+-------+
+|  NAME  | PRICE |
++--------+-------+
+| tea    |  3.50 |
+| coffee | 12.00 |
+| cake   | 10.05 |
+| water  | n/a   |
++--------+-------+
+`
+	)
+	table.SetHeader(header)
+	table.SetColumnDecimals(1, 2)
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_LEFT})
+	table.AppendBulk(data)
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestTitleFunc(t *testing.T) {
+	var (
+		buf    = &bytes.Buffer{}
+		table  = NewWriter(buf)
+		header = []string{"ID", "user_url"}
+		footer = []string{"total", "2"}
+		want   = `+-------+----------+
+|  ID   | User Url |
++-------+----------+
+|     1 | a        |
+|     2 | b        |
++-------+----------+
+| Total |    2     |
++-------+----------+
+`
+	)
+	table.SetTitleFunc(func(s string) string {
+		words := strings.Fields(strings.Replace(s, "_", " ", -1))
+		for i, w := range words {
+			if strings.ToUpper(w) != w {
+				words[i] = strings.ToUpper(w[:1]) + w[1:]
+			}
+		}
+		return strings.Join(words, " ")
+	})
+	table.SetHeader(header)
+	table.SetFooter(footer)
+	table.AppendBulk([][]string{{"1", "a"}, {"2", "b"}})
+	table.Render()
+
+	checkEqual(t, buf.String(), want)
+}
+
+func TestHeaderVerbatimWithoutAutoFormat(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{"snake_case_header", "dotted.name", "lower"})
+	table.SetFooter([]string{"foot_note", "a.b", "c"})
+	table.Append([]string{"1", "2", "3"})
+	table.Render()
+	want := `+-------------------+-------------+-------+
+| snake_case_header | dotted.name | lower |
++-------------------+-------------+-------+
+|                 1 |           2 |     3 |
++-------------------+-------------+-------+
+|     foot_note     |     a.b     |   c   |
++-------------------+-------------+-------+
+`
+	checkEqual(t, buf.String(), want, "verbatim header rendering failed")
+}
+
+func TestHeaderLineWithoutBorders(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Value"})
+	table.EnableBorder(false)
+	table.SetColumnSeparator("")
+	table.SetHeaderLine(true)
+	table.AppendBulk([][]string{{"alpha", "12"}, {"b", "3"}})
+	table.Render()
+
+	want := `  NAME   VALUE  
+----------------
+  alpha     12  
+  b          3  
+`
+	checkEqual(t, buf.String(), want, "header line without borders rendering failed")
+}
+
+func TestCornerSymbols(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"A", "B"})
+	table.SetCornerSymbols("/", "\\", "\\", "/")
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want := `/---+---\
+| A | B |
++---+---+
+| 1 | 2 |
+\---+---/
+`
+	checkEqual(t, buf.String(), want, "corner symbols rendering failed")
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetUnicodeHV(Regular, Regular)
+	table.SetCornerSymbols("╭", "╮", "╰", "╯")
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want = `╭───┬───╮
+│ 1 │ 2 │
+╰───┴───╯
+`
+	checkEqual(t, buf.String(), want, "unicode corner symbols rendering failed")
+}
+
+func TestRowLineStyle(t *testing.T) {
+	data := [][]string{{"a", "1"}, {"b", "2"}, {"c", "3"}}
+	tests := []struct {
+		style int
+		want  string
+	}{
+		{LINE_DASHED, `+-----+-------+
+| KEY | VALUE |
++-----+-------+
+| a   |     1 |
++- - -+- - - -+
+| b   |     2 |
++- - -+- - - -+
+| c   |     3 |
++-----+-------+
+`},
+		{LINE_DOTTED, `+-----+-------+
+| KEY | VALUE |
++-----+-------+
+| a   |     1 |
++·····+·······+
+| b   |     2 |
++·····+·······+
+| c   |     3 |
++-----+-------+
+`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"Key", "Value"})
+		table.SetRowLine(true)
+		table.SetRowLineStyle(tt.style)
+		table.AppendBulk(data)
+		table.Render()
+		checkEqual(t, buf.String(), tt.want, "row line style rendering failed")
+	}
+}
+
+func TestMinWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"A", "B"})
+	table.Append([]string{"1", "2"})
+	table.SetMinWidth(16)
+	table.Render()
+
+	want := `+---+----------+
+| A |    B     |
++---+----------+
+| 1 |        2 |
++---+----------+
+`
+	checkEqual(t, buf.String(), want, "min width rendering failed")
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"A", "B"})
+	table.Append([]string{"1", "2"})
+	table.SetMinWidth(5)
+	table.Render()
+
+	want = `+---+---+
+| A | B |
++---+---+
+| 1 | 2 |
++---+---+
+`
+	checkEqual(t, buf.String(), want, "min width should not shrink the table")
+}
+
+func TestFooterPositionTop(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Amount"})
+	table.SetFooter([]string{"Total", "30"})
+	table.SetFooterPosition(POSITION_TOP)
+	table.AppendBulk([][]string{{"apples", "10"}, {"pears", "20"}})
+	table.Render()
+
+	want := `+--------+--------+
+|  ITEM  | AMOUNT |
++--------+--------+
+| TOTAL  |   30   |
++--------+--------+
+| apples |     10 |
+| pears  |     20 |
++--------+--------+
+`
+	checkEqual(t, buf.String(), want, "top footer rendering failed")
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetUnicodeHV(Regular, Regular)
+	table.SetHeader([]string{"Item", "Amount"})
+	table.SetFooter([]string{"Total", "30"})
+	table.SetFooterPosition(POSITION_TOP)
+	table.SetRowLine(true)
+	table.AppendBulk([][]string{{"apples", "10"}, {"pears", "20"}})
+	table.Render()
+
+	want = `┌────────┬────────┐
+│  ITEM  │ AMOUNT │
+├────────┼────────┤
+│ TOTAL  │   30   │
+├────────┼────────┤
+│ apples │     10 │
+├────────┼────────┤
+│ pears  │     20 │
+└────────┴────────┘
+`
+	checkEqual(t, buf.String(), want, "top footer with row lines rendering failed")
+}
+
+func TestRenderedHeight(t *testing.T) {
+	data := [][]string{
+		{"1/1/2014", "Domain name", "2233", "$10.98"},
+		{"1/1/2014", "January Hosting with a rather long description", "2233", "$54.95"},
+		{"", "    (empty)\n    (empty)", "", ""},
+	}
+	tests := []struct {
+		name   string
+		config func(table *Table)
+	}{
+		{"default", func(table *Table) {}},
+		{"no border", func(table *Table) { table.EnableBorder(false) }},
+		{"no header line", func(table *Table) { table.SetHeaderLine(false) }},
+		{"row line", func(table *Table) { table.SetRowLine(true) }},
+		{"merge cells", func(table *Table) {
+			table.SetAutoMergeCells(true)
+			table.SetRowLine(true)
+		}},
+		{"footer", func(table *Table) { table.SetFooter([]string{"", "", "Total", "$65.93"}) }},
+		{"footer no border", func(table *Table) {
+			table.SetFooter([]string{"", "", "Total", "$65.93"})
+			table.EnableBorder(false)
+		}},
+		{"top footer", func(table *Table) {
+			table.SetFooter([]string{"", "", "Total", "$65.93"})
+			table.SetFooterPosition(POSITION_TOP)
+		}},
+		{"caption", func(table *Table) {
+			table.SetCaption(true, strings.Repeat("A long caption. ", 10))
+		}},
+		{"min width caption", func(table *Table) {
+			table.SetMinWidth(120)
+			table.SetCaption(true, strings.Repeat("A long caption. ", 10))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			table := NewWriter(&buf)
+			table.SetHeader([]string{"Date", "Description", "CV2", "Amount"})
+			table.AppendBulk(data)
+			tt.config(table)
+			got := table.RenderedHeight()
+			table.Render()
+			checkEqual(t, got, strings.Count(buf.String(), "\n"), buf.String())
+		})
+	}
+}
+
+func TestAppendMap(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeaderFromKeys([]string{"name", "role", "age"})
+	table.AppendMap(map[string]string{"age": "42", "name": "Ada", "role": "admin"})
+	table.AppendMap(map[string]string{"name": "Bob", "team": "ignored"})
+	table.Render()
+
+	want := `+------+-------+-----+
+| NAME | ROLE  | AGE |
++------+-------+-----+
+| Ada  | admin |  42 |
+| Bob  |       |     |
++------+-------+-----+
+`
+	checkEqual(t, buf.String(), want, "append map rendering failed")
+}
+
+func TestColumnType(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Phone", "Score", "Count"})
+	table.SetColumnType(0, TYPE_TEXT)
+	table.SetColumnType(1, TYPE_NUMBER)
+	table.SetColumnType(2, TYPE_AUTO)
+	table.AppendBulk([][]string{
+		{"5551234", "n/a", "12345"},
+		{"12", "7", "3"},
+	})
+	table.Render()
+
+	want := `+---------+-------+-------+
+|  PHONE  | SCORE | COUNT |
++---------+-------+-------+
+| 5551234 |   n/a | 12345 |
+| 12      |     7 |     3 |
++---------+-------+-------+
+`
+	checkEqual(t, buf.String(), want, "column type rendering failed")
+}
+
+func TestHeaderBackground(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"A", "B"})
+	table.SetHeaderColor(Colors{Bold}, Colors{})
+	table.SetHeaderBackground(Colors{BgBlueColor})
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want := "+---+---+\n" +
+		"\033[44m| \033[1mA\033[0m\033[44m | B |\033[0m\n" +
+		"+---+---+\n" +
+		"| 1 | 2 |\n" +
+		"+---+---+\n"
+	checkEqual(t, buf.String(), want, "header background rendering failed")
+
+	lines := strings.Split(buf.String(), "\n")
+	checkEqual(t, DisplayWidth(lines[1]), DisplayWidth(lines[0]))
+}
+
+func TestHeaderColWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeaderColWidth(1, 6)
+	table.SetHeader([]string{"Id", "Number of requests"})
+	table.AppendBulk([][]string{{"a", "10"}, {"b", "2000"}})
+	table.Render()
+
+	want := `+----+----------+
+| ID |  NUMBER  |
+|    |    OF    |
+|    | REQUESTS |
++----+----------+
+| a  |       10 |
+| b  |     2000 |
++----+----------+
+`
+	checkEqual(t, buf.String(), want, "header column width rendering failed")
+}
+
+func TestLayout(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Description"})
+	table.SetFooter([]string{"Total", "2"})
+	table.SetColWidth(10)
+	table.AppendBulk([][]string{
+		{"a", "short"},
+		{"b", "a somewhat longer description"},
+	})
+	got := table.Layout()
+	want := TableLayout{
+		ColumnWidths: []int{5, 11},
+		RowHeights:   []int{1, 3},
+		HeaderHeight: 1,
+		FooterHeight: 1,
+		Width:        23,
+		Height:       10,
+	}
+	checkEqual(t, got, want)
+
+	table.Render()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	checkEqual(t, len(lines), got.Height)
+	checkEqual(t, DisplayWidth(lines[0]), got.Width)
+}
+
+func TestRenderMatrix(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Count"})
+	table.SetColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_CENTER})
+	table.Append([]string{"alpha", "1"})
+	table.Append([]string{"b\nbeta", "1,234"})
+	got := table.RenderMatrix()
+	want := [][]string{
+		{"alpha", "  1  "},
+		{"b    ", "1,234"},
+		{"beta ", "     "},
+	}
+	checkEqual(t, got, want)
+	checkEqual(t, buf.String(), "", "RenderMatrix wrote to the output")
+}
+
+func TestTruncateIndicator(t *testing.T) {
+	if runewidth.IsEastAsian() {
+		t.Skip("the ellipsis is ambiguous width in East Asian locales")
+	}
+	data := [][]string{
+		{"a", "short"},
+		{"b", "a much longer description"},
+	}
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Description"})
+	table.SetColWidth(12)
+	table.SetAutoTruncateText(true)
+	table.AppendBulk(data)
+	table.Render()
+
+	want := `+------+--------------+
+| NAME | DESCRIPTION  |
++------+--------------+
+| a    | short        |
+| b    | a much long… |
++------+--------------+
+`
+	checkEqual(t, buf.String(), want, "truncate rendering failed")
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Description"})
+	table.SetColWidth(12)
+	table.SetAutoTruncateText(true)
+	table.SetTruncateIndicator(" »")
+	table.AppendBulk(data)
+	table.Render()
+
+	want = `+------+--------------+
+| NAME | DESCRIPTION  |
++------+--------------+
+| a    | short        |
+| b    | a much lon » |
++------+--------------+
+`
+	checkEqual(t, buf.String(), want, "truncate indicator rendering failed")
+
+	// The text is cut, not the escape sequences
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetColWidth(6)
+	table.SetAutoTruncateText(true)
+	table.Append([]string{"\033[31mabcdefghij\033[0m"})
+	table.Render()
+
+	want = "+--------+\n" +
+		"| \033[31mabcde…\033[0m |\n" +
+		"+--------+\n"
+	checkEqual(t, buf.String(), want, "truncate of a colored cell failed")
+}
+
+func TestEmptyPlaceholder(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Email", "Phone"})
+	table.SetEmptyPlaceholder("N/A")
+	table.AppendBulk([][]string{
+		{"Ada", "", "  "},
+		{"Bob", "bob@example.com", ""},
+	})
+	table.Render()
+
+	want := `+------+-----------------+-------+
+| NAME |      EMAIL      | PHONE |
++------+-----------------+-------+
+| Ada  | N/A             |       |
+| Bob  | bob@example.com | N/A   |
++------+-----------------+-------+
+`
+	checkEqual(t, buf.String(), want, "empty placeholder rendering failed")
+}
+
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestRenderBuffered(t *testing.T) {
+	w := &countingWriter{}
+	table := NewWriter(w)
+	table.SetHeader([]string{"A", "B"})
+	table.SetFooter([]string{"C", "D"})
+	table.SetCaption(true)
+	table.AppendBulk([][]string{{"1", "2"}, {"3", "4"}})
+	table.Render()
+
+	checkEqual(t, w.writes, 1)
+	checkEqual(t, table.out, io.Writer(w))
+
+	var buf bytes.Buffer
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"A", "B"})
+	table.SetFooter([]string{"C", "D"})
+	table.SetCaption(true)
+	table.AppendBulk([][]string{{"1", "2"}, {"3", "4"}})
+	table.Render()
+	checkEqual(t, w.String(), buf.String())
+}
+
+func TestSetOutput(t *testing.T) {
+	var first, second bytes.Buffer
+	table := NewWriter(&first)
+	table.Append([]string{"a", "b"})
+	table.SetOutput(&second)
+	table.Render()
+
+	checkEqual(t, first.String(), "")
+	checkEqual(t, second.String(), `+---+---+
+| a | b |
++---+---+
+`)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
+
+func TestRenderTo(t *testing.T) {
+	var out, first, second bytes.Buffer
+	table := NewWriter(&out)
+	table.Append([]string{"a", "b"})
+	table.RenderTo(&first)
+	if err := table.RenderToErr(&second); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `+---+---+
+| a | b |
++---+---+
+`
+	checkEqual(t, out.String(), "")
+	checkEqual(t, first.String(), want)
+	checkEqual(t, second.String(), want)
+	checkEqual(t, table.out, io.Writer(&out))
+
+	checkEqual(t, table.RenderToErr(failingWriter{}), io.ErrShortWrite)
+	table.SetMaxTableWidthStrict(5)
+	if err := table.RenderToErr(&second); err == nil {
+		t.Error("expected the width error")
+	}
+}
+
+func TestUpdateRow(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.AppendBulk([][]string{{"cpu", "12%"}, {"mem", "40%"}})
+	table.UpdateRow(1, []string{"mem", "41%\nswap 2%"})
+	table.UpdateRow(5, []string{"ignored"})
+	table.Render()
+
+	want := `+-----+---------+
+| cpu | 12%     |
+| mem | 41%     |
+|     | swap 2% |
++-----+---------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestRenderUpdate(t *testing.T) {
+	defer func(f func(io.Writer) bool) { isTerminal = f }(isTerminal)
+	isTerminal = func(io.Writer) bool { return true }
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.AppendBulk([][]string{{"cpu", "12%"}, {"mem", "40%"}})
+	table.RenderUpdate()
+	first := buf.String()
+	checkEqual(t, first, `+-----+-----+
+| cpu | 12% |
+| mem | 40% |
++-----+-----+
+`)
+
+	// Only the changed line is written over the previous table
+	buf.Reset()
+	table.UpdateRow(1, []string{"mem", "47%"})
+	table.RenderUpdate()
+	checkEqual(t, buf.String(), "\033[4A\033[2B\r| mem | 47% |\033[K\n\033[1B")
+
+	buf.Reset()
+	table.RenderUpdate()
+	checkEqual(t, buf.String(), "\033[4A\033[4B")
+
+	// Without terminal the table is rendered again
+	isTerminal = func(io.Writer) bool { return false }
+	buf.Reset()
+	table.RenderUpdate()
+	checkEqual(t, buf.String(), strings.Replace(first, "40%", "47%", 1))
+}
+
+func TestReflowKeepsParagraphs(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColWidth(20)
+	table.Append([]string{"First paragraph\nspread over lines.\n\nSecond one."})
+	table.Render()
+
+	want := `+--------------------+
+| First paragraph    |
+| spread over lines. |
+|                    |
+| Second one.        |
++--------------------+
+`
+	checkEqual(t, buf.String(), want, "reflow with paragraphs failed")
+	checkEqual(t, getParagraphs([]string{"a", "b", "", "", "c", ""}), []string{"a b", "c"})
+	checkEqual(t, getParagraphs([]string{" "}), []string{" "})
+}
+
+func TestColumnVerticalAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Top", "Middle", "Bottom"})
+	table.SetColumnVerticalAlignment([]int{VALIGN_TOP, VALIGN_MIDDLE, VALIGN_BOTTOM})
+	table.SetAutoWrapText(false)
+	table.Append([]string{"a\nb\nc\nd\ne", "1", "x"})
+	table.Render()
+
+	want := `+-----+--------+--------+
+| TOP | MIDDLE | BOTTOM |
++-----+--------+--------+
+| a   |        |        |
+| b   |        |        |
+| c   |      1 |        |
+| d   |        |        |
+| e   |        | x      |
++-----+--------+--------+
+`
+	checkEqual(t, buf.String(), want, "vertical alignment rendering failed")
+}
+
+func TestAppendLines(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Shape", "Art"})
+	table.SetColWidth(4)
+	table.AppendLines([][]string{
+		{"box"},
+		{"+----+", "|    |", "+----+"},
+	})
+	table.Append([]string{"wrapped text", "x"})
+	table.Render()
+
+	want := `+---------+--------+
+|  SHAPE  |  ART   |
++---------+--------+
+| box     | +----+ |
+|         | |    | |
+|         | +----+ |
+| wrapped | x      |
+| text    |        |
++---------+--------+
+`
+	checkEqual(t, buf.String(), want, "append lines rendering failed")
+}
+
+func TestNestedTable(t *testing.T) {
+	var sub bytes.Buffer
+	inner := NewWriter(&sub)
+	inner.SetHeader([]string{"Region", "Sales"})
+	inner.AppendBulk([][]string{{"north", "1"}, {"south", "22"}})
+	inner.Render()
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Quarter", "Breakdown"})
+	table.AppendPreformatted([]string{"Q1", sub.String()})
+	table.Render()
+
+	want := `+---------+--------------------+
+| QUARTER |     BREAKDOWN      |
++---------+--------------------+
+| Q1      | +--------+-------+ |
+|         | | REGION | SALES | |
+|         | +--------+-------+ |
+|         | | north  |     1 | |
+|         | | south  |    22 | |
+|         | +--------+-------+ |
++---------+--------------------+
+`
+	checkEqual(t, buf.String(), want, "nested table rendering failed")
+}
+
+func TestColumnAlignments(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Name", "Count", "Mixed", "Title", "Empty"})
+	table.SetColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_DEFAULT, ALIGN_DEFAULT, ALIGN_CENTER})
+	table.AppendBulk([][]string{
+		{"a", "1", "2", "x", ""},
+		{"b", "1,000", "n/a", "y", ""},
+	})
+	checkEqual(t, table.ColumnAlignments(), []int{ALIGN_LEFT, ALIGN_RIGHT, ALIGN_LEFT, ALIGN_CENTER, ALIGN_LEFT})
+
+	table.SetColumnType(4, TYPE_NUMBER)
+	checkEqual(t, table.ColumnAlignments()[4], ALIGN_RIGHT)
+}
+
+func TestParseAlignment(t *testing.T) {
+	for s, want := range map[string]int{
+		"left":    ALIGN_LEFT,
+		"Right":   ALIGN_RIGHT,
+		" center": ALIGN_CENTER,
+		"default": ALIGN_DEFAULT,
+		"none":    ALIGN_DEFAULT,
+		"":        ALIGN_DEFAULT,
+	} {
+		got, err := ParseAlignment(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkEqual(t, got, want, s)
+	}
+	if _, err := ParseAlignment("middle"); err == nil {
+		t.Error("no error for an unknown alignment")
+	}
+}
+
+func TestColumnAlignmentLength(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Count", "Note"})
+	table.SetAlignment(ALIGN_RIGHT)
+	table.SetColumnAlignment([]int{ALIGN_CENTER})
+	if err := table.Err(); err != nil {
+		t.Fatal(err)
+	}
+	table.Append([]string{"alpha", "1", "x"})
+	table.Append([]string{"b", "22", "yyy"})
+	table.Render()
+
+	want := `+-------+-------+------+
+| NAME  | COUNT | NOTE |
++-------+-------+------+
+| alpha |     1 |    x |
+|   b   |    22 |  yyy |
++-------+-------+------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// A longer slice replaces the previous one and is cut to the columns
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_LEFT, ALIGN_LEFT, ALIGN_CENTER})
+	checkEqual(t, table.Err() != nil, true, "no error for the extra alignment")
+	checkEqual(t, table.ColumnAlignments(), []int{ALIGN_LEFT, ALIGN_LEFT, ALIGN_LEFT})
+
+	// The columns of the rows count, whatever the length of the header
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Name"})
+	table.Append([]string{"alpha", "1"})
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT})
+	if err := table.Err(); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, table.ColumnAlignments(), []int{ALIGN_LEFT, ALIGN_RIGHT})
+}
+
+func TestGrid(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetGrid(true)
+	table.AppendBulk([][]string{
+		{"1", "0", "-12"},
+		{"0", "1", "0"},
+		{"3.5", "0", "100"},
+	})
+	checkEqual(t, table.Layout().ColumnWidths, []int{3, 3, 3})
+	table.Render()
+
+	want := `+-----+-----+-----+
+|  1  |  0  | -12 |
++-----+-----+-----+
+|  0  |  1  |  0  |
++-----+-----+-----+
+| 3.5 |  0  | 100 |
++-----+-----+-----+
+`
+	checkEqual(t, buf.String(), want, "grid rendering failed")
+}
+
+func TestMaxRowHeight(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Log"})
+	table.SetMaxRowHeight(3)
+	table.SetTruncateIndicator("...")
+	table.SetAutoWrapText(false)
+	table.Append([]string{"a", "line 1\nline 2\nline 3\nline 4\nthe longest line 5"})
+	table.Append([]string{"b", "one\ntwo"})
+	table.Render()
+
+	want := `+------+--------+
+| NAME |  LOG   |
++------+--------+
+| a    | line 1 |
+|      | line 2 |
+|      | ...    |
+| b    | one    |
+|      | two    |
++------+--------+
+`
+	checkEqual(t, buf.String(), want, "max row height rendering failed")
+}
+
+func TestIndent(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"A", "B"})
+	table.SetFooter([]string{"C", "D"})
+	table.SetCaption(true, "Caption.")
+	table.SetIndent(2)
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want := `  +---+---+
+  | A | B |
+  +---+---+
+  | 1 | 2 |
+  +---+---+
+  | C | D |
+  +---+---+
+  Caption.
+`
+	checkEqual(t, buf.String(), want, "indent rendering failed")
+	checkEqual(t, table.out, io.Writer(&buf))
+}
+
+func TestPreColoredCells(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Status", "Note"})
+	table.SetColWidth(10)
+	table.AppendBulk([][]string{
+		{"\033[1;32mok\033[0m", "\033[4:3mall good\033[0m"},
+		{"\033[38;5;196mfailed\033[0m", "\033[33mdisk\033[0m \033[33malmost\033[0m full"},
+	})
+	table.Render()
+
+	want := "+--------+------------+\n" +
+		"| STATUS |    NOTE    |\n" +
+		"+--------+------------+\n" +
+		"| \033[1;32mok\033[0m     | \033[4:3mall good\033[0m   |\n" +
+		"| \033[38;5;196mfailed\033[0m | \033[33mdisk\033[0m       |\n" +
+		"|        | \033[33malmost\033[0m     |\n" +
+		"|        | full       |\n" +
+		"+--------+------------+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetAlignedPadding(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"Item", "Qty", "Price"})
+		table.SetAlignedPadding(true)
+		table.SetAutoMergeCells(merge)
+		table.AppendBulk([][]string{{"apple", "3", "1.20"}, {"pear", "12", "0.80"}})
+		table.Render()
+
+		want := `+-------+-----+-------+
+| ITEM  | QTY | PRICE |
++-------+-----+-------+
+| apple |    3|   1.20|
+| pear  |   12|   0.80|
++-------+-----+-------+
+`
+		checkEqual(t, buf.String(), want, ConditionString(merge, "merged cells", "plain rows"))
+	}
+}
+
+func TestMeasureCell(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	lines, w, h := table.MeasureCell("The quick brown fox jumps over the lazy dog.", 16)
+	checkEqual(t, lines, []string{"The quick brown", "fox jumps over", "the lazy dog."})
+	// Like a column, a wrapped cell takes the whole width
+	checkEqual(t, w, 16)
+	checkEqual(t, h, 3)
+
+	// A word longer than the width widens the cell
+	_, w, h = table.MeasureCell("see https://example.com/path", 10)
+	checkEqual(t, w, 24)
+	checkEqual(t, h, 2)
+
+	table.SetMaxRowHeight(2)
+	lines, _, h = table.MeasureCell("one two three", 3)
+	checkEqual(t, lines, []string{"one", ELLIPSIS})
+	checkEqual(t, h, 2)
+}
+
+func TestSetTableAlignment(t *testing.T) {
+	for _, align := range []int{ALIGN_LEFT, ALIGN_CENTER, ALIGN_RIGHT} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"A", "B"})
+		table.SetTableAlignment(align, 20)
+		table.Append([]string{"1", "2"})
+		table.Render()
+
+		want := `+---+---+
+| A | B |
++---+---+
+| 1 | 2 |
++---+---+
+`
+		prefix := map[int]string{ALIGN_CENTER: "     ", ALIGN_RIGHT: "           "}[align]
+		if prefix != "" {
+			want = prefix + strings.Replace(strings.TrimSuffix(want, "\n"), "\n", "\n"+prefix, -1) + "\n"
+		}
+		checkEqual(t, buf.String(), want, fmt.Sprint("alignment ", align))
+	}
+}
+
+func TestColumnSeparatorAt(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Host", "Zone", "CPU", "Mem"})
+	table.SetFooter([]string{"", "Total", "3", "6"})
+	table.SetColumnSeparatorAt(2, "||")
+	table.AppendBulk([][]string{
+		{"web", "eu", "1", "2"},
+		{"db", "us", "2", "4"},
+	})
+	table.Render()
+
+	want := `+------+-------+------+-----+
+| HOST | ZONE  || CPU | MEM |
++------+-------+------+-----+
+| web  | eu    ||   1 |   2 |
+| db   | us    ||   2 |   4 |
++------+-------+------+-----+
+|        TOTAL ||  3  |  6  |
++------+-------+------+-----+
+`
+	checkEqual(t, buf.String(), want, "column separator rendering failed")
+	checkEqual(t, table.Layout().Width, DisplayWidth(strings.Split(want, "\n")[0]))
+}
+
+func TestWideColumnSeparator(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"a", "bb", "c"})
+	table.SetFooter([]string{"", "total", "3"})
+	table.SetColumnSeparator("  |  ")
+	table.Append([]string{"x", "yyy", "1"})
+	table.Render()
+
+	want := `  +-------+-----------+-------+  
+  |   A   |    BB     |   C   |  
+  +-------+-----------+-------+  
+  |   x   |   yyy     |   1   |  
+  +-------+-----------+-------+  
+  |           TOTAL   |   3   |  
+  +-------+-----------+-------+  
+`
+	checkEqual(t, buf.String(), want, "wide column separator rendering failed")
+	checkEqual(t, table.Layout().Width, DisplayWidth(strings.Split(want, "\n")[0]))
+}
+
+func TestSetColumnLeftBorder(t *testing.T) {
+	for _, unicode := range []bool{false, true} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		if unicode {
+			if err := table.SetUnicodeHV(Regular, Regular); err != nil {
+				t.Fatal(err)
+			}
+		}
+		table.SetHeader([]string{"Name", "Q1", "Q2"})
+		table.SetFooter([]string{"Total", "3", "7"})
+		table.SetColumnLeftBorder(1, true)
+		table.AppendBulk([][]string{{"north", "1", "3"}, {"south", "2", "4"}})
+		table.Render()
+
+		want := `+-------++----+----+
+| NAME  || Q1 | Q2 |
++-------++----+----+
+| north ||  1 |  3 |
+| south ||  2 |  4 |
++-------++----+----+
+| TOTAL || 3  | 7  |
++-------++----+----+
+`
+		if unicode {
+			want = `┌───────┰────┬────┐
+│ NAME  ┃ Q1 │ Q2 │
+├───────╂────┼────┤
+│ north ┃  1 │  3 │
+│ south ┃  2 │  4 │
+├───────╂────┼────┤
+│ TOTAL ┃ 3  │ 7  │
+└───────┸────┴────┘
+`
+		}
+		checkEqual(t, buf.String(), want, ConditionString(unicode, "unicode lines", "ascii lines"))
+		checkEqual(t, table.Layout().Width, DisplayWidth(strings.Split(want, "\n")[0]))
+	}
+}
+
+func TestSetCellSpacing(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"a", "b"})
+	table.SetCellSpacing(0)
+	table.Append([]string{"1", "22"})
+	table.Render()
+
+	want := `+-+--+
+|A|B |
++-+--+
+|1|22|
++-+--+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetCellSpacing(2)
+	table.Append([]string{"1", "22"})
+	table.Render()
+
+	want = `+-----+------+
+|  1  |  22  |
++-----+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestStructsKindAlignment(t *testing.T) {
+	type item struct {
+		Code  string
+		Count int
+		Ok    bool
+	}
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	if err := table.SetStructs([]item{{"1", 5, true}, {"abc", 100, false}}); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+------+-------+-------+
+| CODE | COUNT |  OK   |
++------+-------+-------+
+| 1    |     5 | true  |
+| abc  |   100 | false |
++------+-------+-------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.ColumnAlignments(), []int{ALIGN_LEFT, ALIGN_RIGHT, ALIGN_LEFT})
+}
+
+func TestSetNegativeStyle(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Amount"})
+	table.SetNegativeStyle(NEGATIVE_PARENS)
+	table.Append([]string{"Sales", "1200.50"})
+	table.Append([]string{"Refund", "-123.45"})
+	table.Render()
+
+	want := `+--------+----------+
+|  ITEM  |  AMOUNT  |
++--------+----------+
+| Sales  |  1200.50 |
+| Refund | (123.45) |
++--------+----------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetNegativeStyle(NEGATIVE_BOTH)
+	table.Append([]string{"-5"})
+	table.Append([]string{"10"})
+	table.Render()
+
+	want = "+-----+\n" +
+		"| \033[31m(5)\033[0m |\n" +
+		"|  10 |\n" +
+		"+-----+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestCaptionBoxed(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+	table.SetCaption(true, "Movie ratings.")
+	table.SetCaptionBoxed(true)
+	table.Render()
+
+	want := `+------+----------+
+| NAME |   SIGN   |
++------+----------+
+| A    | The Good |
++------+----------+
++-----------------+
+| Movie ratings.  |
++-----------------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.Append([]string{"A", "The Good"})
+	table.SetCaption(true, "Movies.")
+	table.SetCaptionBoxed(true)
+	table.SetCaptionPosition(POSITION_TOP)
+	table.Render()
+
+	want = `+--------------+
+| Movies.      |
++--------------+
++---+----------+
+| A | The Good |
++---+----------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetColumnUnitSplit(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Op", "Latency"})
+	table.SetColumnUnitSplit(1, true)
+	table.Append([]string{"read", "5 ms"})
+	table.Append([]string{"write", "120 ms"})
+	table.Append([]string{"sync", "1000 s"})
+	table.Render()
+
+	want := `+-------+---------+
+|  OP   | LATENCY |
++-------+---------+
+| read  |    5 ms |
+| write |  120 ms |
+| sync  | 1000 s  |
++-------+---------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.Layout().ColumnWidths, []int{5, 7})
+}
+
+func TestUnicodeRowLineJunctions(t *testing.T) {
+	want, err := os.ReadFile("testdata/unicode-rowline.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, merge := range []bool{false, true} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		if err := table.SetUnicodeHV(Regular, Regular); err != nil {
+			t.Fatal(err)
+		}
+		table.SetHeader([]string{"a", "b", "c"})
+		table.SetRowLine(true)
+		table.SetAutoMergeCells(merge)
+		table.AppendBulk([][]string{{"1", "2", "3"}, {"4", "5", "6"}, {"7", "8", "9"}})
+		table.Render()
+		checkEqual(t, buf.String(), string(want), ConditionString(merge, "merged cells", "plain rows"))
+	}
+}
+
+func TestSetEscapeSeparator(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetEscapeSeparator(true)
+	table.SetHeader([]string{"Expr", "Result"})
+	table.Append([]string{"a|b", "true"})
+	table.Render()
+
+	want := `+------+--------+
+| EXPR | RESULT |
++------+--------+
+| a\|b | true   |
++------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestRenderInPieces(t *testing.T) {
+	data := [][]string{{"1", "one"}, {"2", "two"}}
+
+	var full bytes.Buffer
+	table := NewWriter(&full)
+	table.SetHeader([]string{"n", "name"})
+	table.SetFooter([]string{"", "total"})
+	table.AppendBulk(data)
+	table.Render()
+
+	var buf bytes.Buffer
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"n", "name"})
+	table.SetFooter([]string{"", "total"})
+	table.AppendBulk(data)
+	table.RenderHeader()
+	table.RenderRow(0)
+	table.RenderRow(1)
+	table.RenderRow(2)
+	table.RenderFooter()
+	checkEqual(t, buf.String(), full.String())
+
+	buf.Reset()
+	table.RenderHeader()
+	table.RenderHeader()
+	want := `+---+-------+
+| N | NAME  |
++---+-------+
++---+-------+
+| N | NAME  |
++---+-------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// The caption and the footer are printed at the same place as by Render
+	for _, pos := range []int{POSITION_TOP, POSITION_BOTTOM} {
+		newTable := func(w *bytes.Buffer) *Table {
+			table := NewWriter(w)
+			table.SetHeader([]string{"n", "name"})
+			table.SetFooter([]string{"", "total"})
+			table.SetFooterPosition(pos)
+			table.SetCaption(true, "Numbers")
+			table.SetCaptionPosition(pos)
+			table.AppendBulk(data)
+			return table
+		}
+		full.Reset()
+		newTable(&full).Render()
+
+		buf.Reset()
+		table = newTable(&buf)
+		table.RenderHeader()
+		table.RenderRow(0)
+		table.RenderRow(1)
+		table.RenderFooter()
+		checkEqual(t, buf.String(), full.String(), fmt.Sprintf("position %d", pos))
+	}
+}
+
+func TestRichFooter(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Cost"})
+	table.Append([]string{"Tea", "2"})
+	table.RichFooter([]string{"Total", "2"}, []Colors{{}, {Bold, FgGreenColor}})
+	table.Render()
+
+	want := "+-------+------+\n" +
+		"| ITEM  | COST |\n" +
+		"+-------+------+\n" +
+		"| Tea   |    2 |\n" +
+		"+-------+------+\n" +
+		"| TOTAL | \033[1;32m 2  \033[0m |\n" +
+		"+-------+------+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestRichFooterShorterThanRows(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Cost", "Tax"})
+	table.Append([]string{"Tea", "2", "1"})
+	table.RichFooter([]string{"Total"}, []Colors{{Bold}})
+	table.Render()
+
+	want := "+-------+------+-----+\n" +
+		"| ITEM  | COST | TAX |\n" +
+		"+-------+------+-----+\n" +
+		"| Tea   |    2 |   1 |\n" +
+		"+-------+------+-----+\n" +
+		"| \033[1mTOTAL\033[0m |      |     |\n" +
+		"+-------+------+-----+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestNoColor(t *testing.T) {
+	if v, ok := os.LookupEnv("NO_COLOR"); ok {
+		defer os.Setenv("NO_COLOR", v)
+	} else {
+		defer os.Unsetenv("NO_COLOR")
+	}
+	os.Setenv("NO_COLOR", "1")
+
+	render := func(force bool) string {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetForceColor(force)
+		table.SetHeader([]string{"Item", "Cost"})
+		table.SetHeaderColor(Colors{Bold}, Colors{})
+		table.Rich([]string{"Tea", "2"}, []Colors{{FgRedColor}})
+		table.RichFooter([]string{"Total", "2"}, []Colors{{}, {Bold, FgGreenColor}})
+		table.Render()
+		return buf.String()
+	}
+
+	want := `+-------+------+
+| ITEM  | COST |
++-------+------+
+| Tea   |    2 |
++-------+------+
+| TOTAL |  2   |
++-------+------+
+`
+	checkEqual(t, render(false), want)
+	checkEqual(t, strings.Contains(render(true), "\033[1;32m"), true, "SetForceColor did not print the colors")
+
+	// The row colors are applied when printing, after the settings changed
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.Rich([]string{"Tea", "2"}, []Colors{{FgRedColor}})
+	table.SetForceColor(true)
+	table.Render()
+	checkEqual(t, strings.Contains(buf.String(), "\033[31mTea\033[0m"), true, "SetForceColor did not print the row colors")
+
+	// A deterministic output does not depend on the environment
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetDeterministic(true)
+	table.Rich([]string{"Tea", "2"}, []Colors{{FgRedColor}})
+	table.Render()
+	checkEqual(t, strings.Contains(buf.String(), "\033[31mTea\033[0m"), true, "SetDeterministic did not print the colors")
+}
+
+func TestAppendFromReader(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"User", "Shell"})
+	err := table.AppendFromReader(strings.NewReader("root:/bin/bash\r\n\nbob:/bin/zsh"), ":")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, table.NumLines(), 2)
+
+	if err := table.AppendFromReader(strings.NewReader("  ann \t /bin/sh\n"), ""); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+------+-----------+
+| USER |   SHELL   |
++------+-----------+
+| root | /bin/bash |
+| bob  | /bin/zsh  |
+| ann  | /bin/sh   |
++------+-----------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetCellTransform(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetCellTransform(func(row, col int, value string) string {
+		switch {
+		case row == -1:
+			return value + "*"
+		case row >= 0 && col == 1:
+			return strings.Repeat("x", len(value)-2) + value[len(value)-2:]
+		}
+		return value
+	})
+	table.SetHeader([]string{"User", "Token"})
+	table.SetFooter([]string{"count", "2"})
+	table.Append([]string{"ann", "secret-token-42"})
+	table.Append([]string{"bob", "abc"})
+	table.Render()
+
+	want := `+-------+-----------------+
+| USER* |     TOKEN*      |
++-------+-----------------+
+| ann   | xxxxxxxxxxxxx42 |
+| bob   | xbc             |
++-------+-----------------+
+| COUNT |        2        |
++-------+-----------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetTrimTrailingSpace(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetBorder(false)
+	table.SetColWidth(10)
+	table.SetTrimTrailingSpace(true)
+	table.Append([]string{"a", "one two three"})
+	table.Append([]string{"bb", "x"})
+	table.Render()
+
+	want := `  a  | one two
+     | three
+  bb | x
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetStructsWithTag(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"display_name,omitempty"`
+		Note string `json:"-"`
+	}
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	if err := table.SetStructsWithTag([]item{{1, "tea", "hot"}}, "json"); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+----+--------------+------+
+| ID | DISPLAY NAME | NOTE |
++----+--------------+------+
+|  1 | tea          | hot  |
++----+--------------+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestStructsNestedPointers(t *testing.T) {
+	type item struct {
+		A **int
+		B interface{}
+		C interface{}
+		D **testStringerType
+	}
+	n := 7
+	p := &n
+	s := &testStringerType{}
+	var nilInt *int
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	err := table.SetStructs([]item{
+		{A: &p, B: &n, C: testStringerType{}, D: &s},
+		{A: &nilInt, B: nil, C: (*int)(nil)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+-----+-----+------------------+------------------+
+|  A  |  B  |        C         |        D         |
++-----+-----+------------------+------------------+
+|   7 |   7 | testStringerType | testStringerType |
+| nil | nil | nil              | nil              |
++-----+-----+------------------+------------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetStructsNilText(t *testing.T) {
+	type item struct {
+		Name  string
+		Score *int
+	}
+	n := 3
+	for _, text := range []string{"N/A", ""} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetStructsNilText(text)
+		table.SetEmptyPlaceholder("-")
+		if err := table.SetStructs([]item{{"a", &n}, {"b", nil}}); err != nil {
+			t.Fatal(err)
+		}
+		table.Render()
+
+		want := `+------+-------+
+| NAME | SCORE |
++------+-------+
+| a    |     3 |
+| b    |   N/A |
++------+-------+
+`
+		if text == "" {
+			want = strings.Replace(want, "N/A", "  -", 1)
+		}
+		checkEqual(t, buf.String(), want, "nil text "+text)
+	}
+}
+
+func TestSetStructsTimeFormat(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+		Done *time.Time
+	}
+	zone := time.FixedZone("CEST", 2*60*60)
+	at := time.Date(2024, 5, 17, 16, 4, 5, 123456789, zone)
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetStructsTimeFormat("2006-01-02T15:04:05.000Z07:00")
+	table.SetStructsTimeLocation(time.UTC)
+	if err := table.SetStructs([]event{{"deploy", at, &at}, {"rollback", at, nil}}); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+----------+--------------------------+--------------------------+
+|   NAME   |            AT            |           DONE           |
++----------+--------------------------+--------------------------+
+| deploy   | 2024-05-17T14:04:05.123Z | 2024-05-17T14:04:05.123Z |
+| rollback | 2024-05-17T14:04:05.123Z | nil                      |
++----------+--------------------------+--------------------------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetStructsTimeFormat("3:04PM MST")
+	if err := table.SetStructs([]event{{"deploy", at, nil}}); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+	checkEqual(t, strings.Contains(buf.String(), "| 4:04PM CEST |"), true, buf.String())
+}
+
+func TestAlignTables(t *testing.T) {
+	var buf bytes.Buffer
+	north := NewWriter(&buf)
+	north.SetHeader([]string{"City", "Sales"})
+	north.Append([]string{"Oslo", "12"})
+	south := NewWriter(&buf)
+	south.SetHeader([]string{"City", "Sales"})
+	south.Append([]string{"Johannesburg", "1337"})
+	AlignTables(north, south)
+	north.Render()
+	south.Render()
+
+	want := `+--------------+-------+
+|     CITY     | SALES |
++--------------+-------+
+| Oslo         |    12 |
++--------------+-------+
++--------------+-------+
+|     CITY     | SALES |
++--------------+-------+
+| Johannesburg |  1337 |
++--------------+-------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetColumnAlignmentByName(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Total Cost"})
+	if err := table.SetColumnAlignmentByName("total  cost", ALIGN_CENTER); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetColumnAlignmentByName("Missing", ALIGN_LEFT); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+	table.Append([]string{"tea", "2"})
+	table.Render()
+
+	want := `+------+------------+
+| NAME | TOTAL COST |
++------+------------+
+| tea  |     2      |
++------+------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetDeterministic(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetBorder(false)
+	table.SetNewLine("\r\n")
+	table.SetDeterministic(true)
+	table.SetHeader([]string{"Name", "Id"})
+	table.Append([]string{"a", "1"})
+	table.Render()
+
+	want := `  NAME | ID
+-------+-----
+  a    |  1
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetDropEmptyColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "", "Age"})
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_LEFT, ALIGN_CENTER})
+	table.SetDropEmptyColumns(true)
+	table.Append([]string{"Ann", "", "30"})
+	table.Append([]string{"Bob", " ", "4"})
+	table.Render()
+
+	want := `+------+-----+
+| NAME | AGE |
++------+-----+
+| Ann  | 30  |
+| Bob  |  4  |
++------+-----+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.Layout().ColumnWidths, []int{4, 3})
+	checkEqual(t, table.Layout().Width, 14)
+	checkEqual(t, table.ColumnAlignments(), []int{ALIGN_LEFT, ALIGN_CENTER})
+}
+
+func TestSetLegend(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Test", "Linux", "macOS"})
+	table.SetCaption(true, "Nightly build.")
+	table.SetLegend([]string{"+ passed", "- failed", "? flaky, rerun twice", "s skipped"})
+	table.Append([]string{"unit", "+", "+"})
+	table.Append([]string{"e2e", "?", "-"})
+	table.Render()
+
+	want := `+------+-------+-------+
+| TEST | LINUX | MACOS |
++------+-------+-------+
+| unit | +     | +     |
+| e2e  | ?     | -     |
++------+-------+-------+
+Nightly build.
++ passed  - failed
+? flaky, rerun twice
+s skipped
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+}
+
+func TestAppendSectionLine(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Qty", "Price"})
+	table.AppendSectionLine("Fruit")
+	table.Append([]string{"apple", "3", "1.20"})
+	table.Append([]string{"pear", "1", "0.80"})
+	table.AppendSectionLine("Vegetables")
+	table.Append([]string{"leek", "2", "2.10"})
+	table.Render()
+
+	want := `+-------+-----+-------+
+| NAME  | QTY | PRICE |
++--- Fruit ---+-------+
+| apple |   3 |  1.20 |
+| pear  |   1 |  0.80 |
++--- Vegetables ------+
+| leek  |   2 |  2.10 |
++-------+-----+-------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+}
+
+func TestSetMaxRows(t *testing.T) {
+	if runewidth.IsEastAsian() {
+		t.Skip("skipping test; ellipsis width depends on locale")
+	}
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Name"})
+	table.SetMaxRows(2)
+	for i := 0; i < 1286; i++ {
+		table.Append([]string{fmt.Sprint(i), "item"})
+	}
+	table.Render()
+
+	want := `+------+------------+
+|  ID  |    NAME    |
++------+------------+
+|    0 | item       |
+|    1 | item       |
+| … 1,284 more rows |
++-------------------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+
+	// The count widens the table only while it is printed
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Name"})
+	table.SetMaxRows(1)
+	table.AppendBulk([][]string{{"0", "a"}, {"1", "b"}})
+	table.Render()
+	table.SetMaxRows(0)
+	table.Render()
+
+	want = `+----+---------+
+| ID |  NAME   |
++----+---------+
+|  0 | a       |
+| … 1 more row |
++--------------+
++----+------+
+| ID | NAME |
++----+------+
+|  0 | a    |
+|  1 | b    |
++----+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetEmptyMessage(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Name", "Status"})
+	table.SetEmptyMessage("(no rows)")
+	table.Render()
+
+	want := `+----+------+--------+
+| ID | NAME | STATUS |
++----+------+--------+
+|     (no rows)      |
++--------------------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+
+	// The message widens the table only while it is printed
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Id"})
+	table.SetRowLine(true)
+	table.SetEmptyMessage("nothing found")
+	table.Render()
+	table.Append([]string{"1"})
+	table.Render()
+
+	want = `+---------------+
+|      ID       |
++---------------+
+| nothing found |
++---------------+
++----+
+| ID |
++----+
+|  1 |
++----+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestHeaderWithoutRows(t *testing.T) {
+	for _, rowLine := range []bool{false, true} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"Id", "Name"})
+		table.SetRowLine(rowLine)
+		table.Render()
+
+		want := `+----+------+
+| ID | NAME |
++----+------+
+`
+		checkEqual(t, buf.String(), want, fmt.Sprint("row line ", rowLine))
+		checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+	}
+
+	// Without bottom border the header line is kept
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Name"})
+	table.SetBorders(Border{Left: true, Top: true, Right: true, Bottom: false})
+	table.Render()
+	want := `+----+------+
+| ID | NAME |
++----+------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+
+	// A top footer is closed once, by its separator or by the bottom line
+	for _, rowLine := range []bool{false, true} {
+		for _, sep := range []bool{false, true} {
+			buf.Reset()
+			table = NewWriter(&buf)
+			table.SetHeader([]string{"Id", "Name"})
+			table.SetFooter([]string{"", "none"})
+			table.SetFooterPosition(POSITION_TOP)
+			table.SetFooterSeparator(sep)
+			table.SetRowLine(rowLine)
+			table.Render()
+
+			want = `+----+------+
+| ID | NAME |
++----+------+
+|    | NONE |
++----+------+
+`
+			msg := fmt.Sprintf("row line %v separator %v", rowLine, sep)
+			checkEqual(t, buf.String(), want, msg)
+			checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"), msg)
+		}
+	}
+}
+
+func TestSetHeaderCase(t *testing.T) {
+	for _, tt := range []struct {
+		c    int
+		want string
+	}{
+		{CASE_TITLE, "| FIRST NAME |"},
+		{CASE_UPPER, "| FIRST_NAME |"},
+		{CASE_LOWER, "| first_name |"},
+		{CASE_NONE, "| First_name |"},
+	} {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeaderCase(tt.c)
+		table.SetHeader([]string{"First_name"})
+		table.Render()
+		checkEqual(t, strings.Split(buf.String(), "\n")[1], tt.want)
+	}
+}
+
+func TestSetColumnLine(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Date", "Item", "Amount"})
+	table.SetFooter([]string{"", "Total", "12"})
+	table.SetRowLine(true)
+	table.SetColumnLine(false)
+	table.Append([]string{"1/1", "Tea", "2"})
+	table.Append([]string{"1/2", "Cake", "10"})
+	table.Render()
+
+	want := `+-----------------------+
+| DATE   ITEM    AMOUNT |
++-----------------------+
+| 1/1    Tea          2 |
++-----------------------+
+| 1/2    Cake        10 |
++-----------------------+
+|        TOTAL     12   |
++-----------------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetDecimalSeparator(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Price", "Rate"})
+	table.SetDecimalSeparator(',')
+	table.SetColumnDecimals(1, 2)
+	table.SetColumnDecimals(2, 1)
+	table.Append([]string{"Tea", "12,5", "0,75"})
+	table.Append([]string{"Cake", "1.234,5", "3"})
+	table.Render()
+
+	want := `+------+---------+------+
+| ITEM |  PRICE  | RATE |
++------+---------+------+
+| Tea  |   12,50 |  0,8 |
+| Cake | 1234,50 |  3,0 |
++------+---------+------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestVisitCells(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"name", "qty"})
+	table.SetFooter([]string{"total", "3"})
+	table.Append([]string{"tea\ncake", "3"})
+
+	var got []string
+	table.VisitCells(func(row, col, lineIdx int, text string, width, align int) {
+		got = append(got, fmt.Sprintf("%d,%d,%d %q w=%d a=%d", row, col, lineIdx, text, width, align))
+	})
+	want := []string{
+		`-1,0,0 "NAME" w=5 a=1`,
+		`-1,1,0 "QTY" w=3 a=1`,
+		`0,0,0 "tea" w=5 a=3`,
+		`0,0,1 "cake" w=5 a=3`,
+		`0,1,0 "3" w=3 a=2`,
+		`0,1,1 "" w=3 a=3`,
+		`-2,0,0 "TOTAL" w=5 a=1`,
+		`-2,1,0 "3" w=3 a=1`,
+	}
+	checkEqual(t, got, want)
+
+	// The widths are those computed by Render
+	table = NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"name", "qty"})
+	table.SetMinWidth(20)
+	table.Append([]string{"tea", "3"})
+	var widths []int
+	table.VisitCells(func(row, col, lineIdx int, text string, width, align int) {
+		if row == 0 {
+			widths = append(widths, width)
+		}
+	})
+	checkEqual(t, widths, []int{4, 9})
+}
+
+func TestAutoMergeCellsHorizontal(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Region", "Q1", "Q2", "Q3"})
+	table.SetAutoMergeCellsHorizontal(true)
+	table.SetRowLine(true)
+	table.Append([]string{"North", "closed", "closed", "12"})
+	table.Append([]string{"South", "10", "11", "12"})
+	table.Append([]string{"East", "n/a", "n/a", "n/a"})
+	table.Render()
+
+	want := `+--------+--------+--------+-----+
+| REGION |   Q1   |   Q2   | Q3  |
++--------+--------+--------+-----+
+| North  | closed          |  12 |
++--------+--------+--------+-----+
+| South  |     10 |     11 |  12 |
++--------+--------+--------+-----+
+| East   | n/a                   |
++--------+-----------------------+
+`
+	checkEqual(t, buf.String(), want)
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	if err := table.SetUnicodeHV(Regular, Regular); err != nil {
+		t.Fatal(err)
+	}
+	table.SetAutoMergeCellsHorizontal(true)
+	table.SetRowLine(true)
+	table.Append([]string{"North", "closed", "closed", "12"})
+	table.Append([]string{"South", "10", "11", "12"})
+	table.Append([]string{"East", "n/a", "n/a", "n/a"})
+	table.Render()
+
+	want = `┌───────┬─────────────────┬─────┐
+│ North │ closed          │  12 │
+├───────┼────────┬────────┼─────┤
+│ South │     10 │     11 │  12 │
+├───────┼────────┴────────┴─────┤
+│ East  │ n/a                   │
+└───────┴───────────────────────┘
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetMaxTableWidthStrict(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetMaxTableWidthStrict(20)
+	table.Append([]string{"a", "b"})
+	table.SetColMinWidth(1, 20)
+	table.Render()
+
+	if table.Err() == nil {
+		t.Fatal("expected a width error")
+	}
+	checkEqual(t, table.Err().Error(), "table width 28 exceeds the maximum width 20")
+	checkEqual(t, buf.String(), "")
+
+	table.SetMaxTableWidthStrict(28)
+	table.Render()
+	if err := table.Err(); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, strings.Count(buf.String(), "\n"), 3)
+
+	// The rows and the footer printed in pieces are checked too
+	buf.Reset()
+	table.SetMaxTableWidthStrict(20)
+	table.RenderRow(0)
+	checkEqual(t, table.Err() != nil, true, "no width error for RenderRow")
+	table.RenderFooter()
+	checkEqual(t, table.Err() != nil, true, "no width error for RenderFooter")
+	checkEqual(t, buf.String(), "")
+	checkEqual(t, table.RenderMatrix() == nil, true, "a matrix for a too wide table")
+}
+
+func TestAsymmetricBorders(t *testing.T) {
+	tests := []struct {
+		border Border
+		want   string
+	}{
+		{Border{Left: true, Top: true, Bottom: true}, `┌───┬────
+│ A │ B  
+├───┼────
+│ 1 │ 2  
+├───┼────
+│ 3 │ 4  
+└───┴────
+`},
+		{Border{Right: true, Top: true, Bottom: true}, `────┬───┐
+  A │ B │
+────┼───┤
+  1 │ 2 │
+────┼───┤
+  3 │ 4 │
+────┴───┘
+`},
+		{Border{Left: true, Right: true, Top: true}, `┌───┬───┐
+│ A │ B │
+├───┼───┤
+│ 1 │ 2 │
+├───┼───┤
+│ 3 │ 4 │
+`},
+		{Border{Left: true, Right: true, Bottom: true}, `│ A │ B │
+├───┼───┤
+│ 1 │ 2 │
+├───┼───┤
+│ 3 │ 4 │
+└───┴───┘
+`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		if err := table.SetUnicodeHV(Regular, Regular); err != nil {
+			t.Fatal(err)
+		}
+		table.SetHeader([]string{"a", "b"})
+		table.SetRowLine(true)
+		table.SetBorders(tt.border)
+		table.AppendBulk([][]string{{"1", "2"}, {"3", "4"}})
+		table.Render()
+		checkEqual(t, buf.String(), tt.want, fmt.Sprintf("%+v", tt.border))
+		checkEqual(t, table.RenderedHeight(), strings.Count(tt.want, "\n"), fmt.Sprintf("%+v height", tt.border))
+	}
+}
+
+func TestSetRowRenderedCallback(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		var buf, plain bytes.Buffer
+		data := [][]string{{"a", "1"}, {"a", "2"}, {"b", "3"}}
+		table := NewWriter(&buf)
+		table.SetAutoMergeCells(merge)
+		var rendered []int
+		table.SetRowRenderedCallback(func(rowIdx int) {
+			rendered = append(rendered, rowIdx)
+		})
+		table.AppendBulk(data)
+		table.Render()
+
+		other := NewWriter(&plain)
+		other.SetAutoMergeCells(merge)
+		other.AppendBulk(data)
+		other.Render()
+
+		msg := ConditionString(merge, "merged cells", "plain rows")
+		checkEqual(t, rendered, []int{0, 1, 2}, msg)
+		checkEqual(t, buf.String(), plain.String(), msg)
+	}
+}
+
+func TestSetGutterColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Q1", "Q2", "Q3"})
+	table.SetFooter([]string{"Total", "3", "7", "1"})
+	table.SetGutterColumns([]int{1, 4})
+	table.AppendBulk([][]string{{"north", "1", "3", "0"}, {"south", "2", "4", "1"}})
+	table.Render()
+
+	want := `+-------+---+----+----+---+----+
+| NAME  |   | Q1 | Q2 |   | Q3 |
++-------+---+----+----+---+----+
+| north |   |  1 |  3 |   |  0 |
+| south |   |  2 |  4 |   |  1 |
++-------+---+----+----+---+----+
+| TOTAL |   | 3  | 7  |   | 1  |
++-------+---+----+----+---+----+
+`
+	checkEqual(t, buf.String(), want)
+
+	// The gutters are not stored, a row appended later is laid out the same
+	buf.Reset()
+	table.Append([]string{"east", "5", "6", "2"})
+	table.Render()
+	want = `+-------+---+----+----+---+----+
+| NAME  |   | Q1 | Q2 |   | Q3 |
++-------+---+----+----+---+----+
+| north |   |  1 |  3 |   |  0 |
+| south |   |  2 |  4 |   |  1 |
+| east  |   |  5 |  6 |   |  2 |
++-------+---+----+----+---+----+
+| TOTAL |   | 3  | 7  |   | 1  |
++-------+---+----+----+---+----+
+`
+	checkEqual(t, buf.String(), want)
+
+	// The lines added to fill a row fit in the gutters
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Note"})
+	table.SetGutterColumns([]int{1})
+	table.Append([]string{"alpha", "first\nsecond"})
+	table.Render()
+	want = `+-------+---+--------+
+| NAME  |   |  NOTE  |
++-------+---+--------+
+| alpha |   | first  |
+|       |   | second |
++-------+---+--------+
+`
+	checkEqual(t, buf.String(), want)
+	layout := table.Layout()
+	checkEqual(t, layout.ColumnWidths, []int{5, 1, 6})
+	checkEqual(t, layout.Width, 22)
+}
+
+func TestSetFooterSeparator(t *testing.T) {
+	separated := `+---+---+
+| A | B |
++---+---+
+| 1 | 2 |
++---+---+
+| X | Y |
++---+---+
+`
+	joined := `+---+---+
+| A | B |
++---+---+
+| 1 | 2 |
+| X | Y |
++---+---+
+`
+	tests := []struct {
+		bottom, sep bool
+		want        string
+	}{
+		{true, true, separated},
+		{true, false, joined},
+		{false, true, separated},
+		{false, false, joined},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"a", "b"})
+		table.SetFooter([]string{"x", "y"})
+		table.SetBorders(Border{Left: true, Right: true, Top: true, Bottom: tt.bottom})
+		table.SetFooterSeparator(tt.sep)
+		table.Append([]string{"1", "2"})
+		table.Render()
+
+		msg := fmt.Sprintf("bottom %v separator %v", tt.bottom, tt.sep)
+		checkEqual(t, buf.String(), tt.want, msg)
+		checkEqual(t, table.RenderedHeight(), strings.Count(tt.want, "\n"), msg)
+	}
+}
+
+func TestSetElideColumns(t *testing.T) {
+	if runewidth.IsEastAsian() {
+		t.Skip("the ellipsis is ambiguous width in East Asian locales")
+	}
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Region", "CPU", "Memory"})
+	table.SetMaxTableWidthStrict(25)
+	table.SetElideColumns(true)
+	table.AppendBulk([][]string{{"web", "eu-west", "1", "2"}, {"db", "us-east", "2", "4"}})
+	table.Render()
+
+	want := `+------+---------+---+
+| NAME | REGION  | … |
++------+---------+---+
+| web  | eu-west | … |
+| db   | us-east | … |
++------+---------+---+
+`
+	checkEqual(t, buf.String(), want)
+	if err := table.Err(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	checkEqual(t, table.Layout().Width, 22)
+	checkEqual(t, table.Layout().ColumnWidths, []int{4, 7, 1})
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+
+	// The columns are only elided in the output, a wider limit shows them
+	buf.Reset()
+	table.SetMaxTableWidthStrict(40)
+	table.Render()
+	want = `+------+---------+-----+--------+
+| NAME | REGION  | CPU | MEMORY |
++------+---------+-----+--------+
+| web  | eu-west |   1 |      2 |
+| db   | us-east |   2 |      4 |
++------+---------+-----+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetWidthFunc(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	// Every star takes two columns
+	table.SetWidthFunc(func(s string) int {
+		return len([]rune(s)) + strings.Count(s, "★")
+	})
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"Tea", "★★★"})
+	table.Append([]string{"Cake", "★"})
+	table.Render()
+
+	want := `+------+--------+
+| NAME | RATING |
++------+--------+
+| Tea  | ★★★ |
+| Cake | ★     |
++------+--------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetSelectionColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Package", "Version"})
+	table.SetSelectionColumn(map[int]bool{1: true}, "[x]", "[ ]")
+	table.Append([]string{"runewidth", "0.0.10"})
+	table.Append([]string{"tablewriter", "0.0.5"})
+	table.Render()
+
+	want := `+-----+-------------+---------+
+|     |   PACKAGE   | VERSION |
++-----+-------------+---------+
+| [ ] | runewidth   | 0.0.10  |
+| [x] | tablewriter | 0.0.5   |
++-----+-------------+---------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// Rendering again does not add another column
+	buf.Reset()
+	table.Render()
+	checkEqual(t, buf.String(), want)
+
+	// A row appended after a render gets its glyph too
+	buf.Reset()
+	table.Append([]string{"color", "1.9.0"})
+	table.Render()
+	want = `+-----+-------------+---------+
+|     |   PACKAGE   | VERSION |
++-----+-------------+---------+
+| [ ] | runewidth   | 0.0.10  |
+| [x] | tablewriter | 0.0.5   |
+| [ ] | color       | 1.9.0   |
++-----+-------------+---------+
+`
+	checkEqual(t, buf.String(), want)
+
+	// One character glyphs stay lined up on the rows of several lines
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Task", "Due"})
+	table.SetSelectionColumn(map[int]bool{0: true}, "x", " ")
+	table.Append([]string{"write\ntests", "1"})
+	table.Render()
+	want = `+---+-------+-----+
+|   | TASK  | DUE |
++---+-------+-----+
+| x | write |   1 |
+|   | tests |     |
++---+-------+-----+
+`
+	checkEqual(t, buf.String(), want)
+	layout := table.Layout()
+	checkEqual(t, layout.ColumnWidths, []int{1, 5, 3})
+	checkEqual(t, layout.Width, 19)
+	checkEqual(t, table.ColumnAlignments(), []int{ALIGN_CENTER, ALIGN_LEFT, ALIGN_RIGHT})
+}
+
+func TestHeaderShorterThanRows(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"a", "b"})
+	table.SetHeaderColor(Colors{Bold}, Colors{Bold})
+	table.AppendBulk([][]string{{"1", "2", "3", "4"}, {"5", "6", "7", "8"}})
+	table.Render()
+
+	want := "+---+---+---+---+\n" +
+		"| \033[1mA\033[0m | \033[1mB\033[0m |   |   |\n" +
+		"+---+---+---+---+\n" +
+		"| 1 | 2 | 3 | 4 |\n" +
+		"| 5 | 6 | 7 | 8 |\n" +
+		"+---+---+---+---+\n"
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetCollapseBlankRows(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Key", "Value"})
+	table.SetCollapseBlankRows(true)
+	table.SetRowLine(true)
+	table.Append([]string{"a", "1"})
+	table.Append([]string{"", " "})
+	table.Append([]string{"", ""})
+	table.Append([]string{"b", "2"})
+	table.Append([]string{"", ""})
+	table.Render()
+
+	want := `+-----+-------+
+| KEY | VALUE |
++-----+-------+
+| a   |     1 |
++-----+-------+
+| b   |     2 |
++-----+-------+
+`
+	checkEqual(t, buf.String(), want)
+	checkEqual(t, table.RenderedHeight(), strings.Count(want, "\n"))
+	checkEqual(t, table.Layout().RowHeights, []int{1, 1})
+
+	// A collapsed row prints nothing when rendered alone
+	buf.Reset()
+	table.RenderRow(2)
+	table.RenderRow(4)
+	checkEqual(t, buf.String(), "")
+	table.RenderRow(3)
+	checkEqual(t, buf.String(), "| b   |     2 |\n+-----+-------+\n")
+}
@@ -2,6 +2,7 @@ package tablewriter
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -101,6 +102,34 @@ func format(s string, codes interface{}) string {
 	return startFormat(seq) + s + stopFormat()
 }
 
+// SetForceColor Print the colors even when NO_COLOR is set
+// By default no color is printed when the NO_COLOR environment variable
+// is set to a non-empty value, see https://no-color.org.
+func (t *Table) SetForceColor(force bool) {
+	t.forceColor = force
+}
+
+// colored - check if the table prints its colors
+func (t *Table) colored() bool {
+	return t.forceColor || t.deterministic || os.Getenv("NO_COLOR") == ""
+}
+
+// colorize - format s with codes, unless the colors are off
+func (t *Table) colorize(s string, codes interface{}) string {
+	if !t.colored() {
+		return s
+	}
+	return format(s, codes)
+}
+
+// colorizeLine - formatLine unless the colors are off
+func (t *Table) colorizeLine(s string, seq string) string {
+	if !t.colored() {
+		return s
+	}
+	return formatLine(s, seq)
+}
+
 // Adding header colors (ANSI codes)
 func (t *Table) SetHeaderColor(colors ...Colors) {
 	if t.colSize != len(colors) {
@@ -111,6 +140,22 @@ func (t *Table) SetHeaderColor(colors ...Colors) {
 	}
 }
 
+// Adding header background (ANSI codes)
+// The background covers the whole header line, padding and separators included
+func (t *Table) SetHeaderBackground(colors Colors) {
+	t.headerBgParams = makeSequence(colors)
+}
+
+// Adding ANSI escape sequences around a whole line
+// The sequence is restored after each reset inside the line
+func formatLine(s string, seq string) string {
+	if len(seq) == 0 {
+		return s
+	}
+	s = strings.Replace(s, stopFormat(), stopFormat()+startFormat(seq), -1)
+	return startFormat(seq) + s + stopFormat()
+}
+
 // Adding column colors (ANSI codes)
 func (t *Table) SetColumnColor(colors ...Colors) {
 	if t.colSize != len(colors) {
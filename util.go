@@ -8,16 +8,28 @@
 package tablewriter
 
 import (
+	"bytes"
+	"io"
 	"math"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mattn/go-runewidth"
 )
 
-var ansi = regexp.MustCompile("\033\\[(?:[0-9]{1,3}(?:;[0-9]{1,3})*)?[m|K]")
+// ansi matches the CSI escape sequences, such as the SGR colors "\033[31m"
+var ansi = regexp.MustCompile("\033\\[[0-?]*[ -/]*[@-~]")
 
+// DisplayWidth Width of a string on a terminal
+// ANSI escape sequences are not counted, so pre-colored text is measured
+// by its visible characters.
 func DisplayWidth(str string) int {
+	if strings.IndexByte(str, '\033') < 0 {
+		return runewidth.StringWidth(str)
+	}
 	return runewidth.StringWidth(ansi.ReplaceAllLiteralString(str, ""))
 }
 
@@ -63,7 +75,12 @@ func Title(name string) string {
 // Pad String
 // Attempts to place string in the center
 func Pad(s, pad string, width int) string {
-	gap := width - DisplayWidth(s)
+	return padWidth(s, pad, width, DisplayWidth)
+}
+
+// padWidth - Pad measuring s with sw
+func padWidth(s, pad string, width int, sw func(string) int) string {
+	gap := width - sw(s)
 	if gap > 0 {
 		gapLeft := int(math.Ceil(float64(gap / 2)))
 		gapRight := gap - gapLeft
@@ -75,7 +92,12 @@ func Pad(s, pad string, width int) string {
 // PadRight Pad String Right position
 // This would place string at the left side of the screen
 func PadRight(s, pad string, width int) string {
-	gap := width - DisplayWidth(s)
+	return padRightWidth(s, pad, width, DisplayWidth)
+}
+
+// padRightWidth - PadRight measuring s with sw
+func padRightWidth(s, pad string, width int, sw func(string) int) string {
+	gap := width - sw(s)
 	if gap > 0 {
 		return s + strings.Repeat(string(pad), gap)
 	}
@@ -85,9 +107,208 @@ func PadRight(s, pad string, width int) string {
 // PadLeft Pad String Left position
 // This would place string at the right side of the screen
 func PadLeft(s, pad string, width int) string {
-	gap := width - DisplayWidth(s)
+	return padLeftWidth(s, pad, width, DisplayWidth)
+}
+
+// padLeftWidth - PadLeft measuring s with sw
+func padLeftWidth(s, pad string, width int, sw func(string) int) string {
+	gap := width - sw(s)
 	if gap > 0 {
 		return strings.Repeat(string(pad), gap) + s
 	}
 	return s
 }
+
+// parseFloat Parse a finite float, ignoring surrounding spaces
+func parseFloat(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, false
+	}
+	return f, true
+}
+
+// formatThousands - format an integer with a comma between groups of three digits
+func formatThousands(n int) string {
+	s := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
+
+// HumanizeNumber Format a number in compact form with a K, M, B or T suffix
+// 1500000 is written "1.5M". Numbers below a thousand get no suffix. All are
+// rounded to one decimal, trailing zeros dropped, so 12.345 is "12.3".
+func HumanizeNumber(n float64) string {
+	return humanizeNumber(n, 1)
+}
+
+// humanizeNumber - HumanizeNumber with at most places decimals
+func humanizeNumber(n float64, places int) string {
+	return humanizeUnits(n, 1000, places, "", []string{"", "K", "M", "B", "T"})
+}
+
+// HumanizeBytes Format a byte size with IEC units
+// 1610612736 is written "1.5 GiB", sizes below 1024 like "512 B".
+func HumanizeBytes(n int64) string {
+	return humanizeBytes(float64(n), 1, false)
+}
+
+// humanizeBytes - HumanizeBytes with at most places decimals, in SI units if si is set
+func humanizeBytes(n float64, places int, si bool) string {
+	if si {
+		return humanizeUnits(n, 1000, places, SPACE, []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"})
+	}
+	return humanizeUnits(n, 1024, places, SPACE, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"})
+}
+
+// HumanizeDuration Format a duration in compact form
+// The two largest units are kept, like "1h23m" or "2d4h", and durations below
+// a minute are written with a single unit, like "450ms" or "12.5s".
+func HumanizeDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	const day = 24 * time.Hour
+	units := []struct {
+		size time.Duration
+		name string
+	}{{day, "d"}, {time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}}
+
+	// Each unit is checked after rounding, so that 999.7ms is written "1s"
+	switch {
+	case d == 0:
+		return "0s"
+	case d < time.Microsecond:
+		return sign + strconv.FormatInt(int64(d), 10) + "ns"
+	case d.Round(time.Microsecond) < time.Millisecond:
+		return sign + strconv.FormatInt(int64(d.Round(time.Microsecond)/time.Microsecond), 10) + "µs"
+	case d.Round(time.Millisecond) < time.Second:
+		return sign + strconv.FormatInt(int64(d.Round(time.Millisecond)/time.Millisecond), 10) + "ms"
+	case d.Round(100*time.Millisecond) < time.Minute:
+		return sign + humanizeUnits(d.Round(100*time.Millisecond).Seconds(), 60, 1, "", []string{"s"})
+	}
+	for i := 0; ; i++ {
+		r := d.Round(units[i+1].size)
+		if r < units[i].size && i+2 < len(units) {
+			continue
+		}
+		s := strconv.FormatInt(int64(r/units[i].size), 10) + units[i].name
+		if rest := r % units[i].size / units[i+1].size; rest > 0 {
+			s += strconv.FormatInt(int64(rest), 10) + units[i+1].name
+		}
+		return sign + s
+	}
+}
+
+// humanizeUnits - divide n by base up to the largest unit it reaches
+// The number is written with at most places decimals, then sep and the unit.
+func humanizeUnits(n, base float64, places int, sep string, units []string) string {
+	i := 0
+	for i < len(units)-1 && math.Abs(n) >= base {
+		n /= base
+		i++
+	}
+	s := strconv.FormatFloat(n, 'f', places, 64)
+	// Rounding may reach the next unit, as 999999 rounds to 1000.0K
+	if f, _ := strconv.ParseFloat(s, 64); math.Abs(f) >= base && i < len(units)-1 {
+		n /= base
+		i++
+		s = strconv.FormatFloat(n, 'f', places, 64)
+	}
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s + sep + units[i]
+}
+
+// indentWriter Writer adding a prefix at the beginning of each line
+// Without prefix, indent gives the number of spaces on the first write.
+type indentWriter struct {
+	w      io.Writer
+	prefix []byte
+	indent func() int
+	bol    bool
+}
+
+func (iw *indentWriter) Write(p []byte) (int, error) {
+	if iw.prefix == nil && iw.indent != nil {
+		iw.prefix = []byte(strings.Repeat(SPACE, iw.indent()))
+	}
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(p, []byte(NEWLINE)) {
+		if len(line) == 0 {
+			continue
+		}
+		if iw.bol {
+			buf.Write(iw.prefix)
+		}
+		buf.Write(line)
+		iw.bol = line[len(line)-1] == '\n'
+	}
+	if _, err := iw.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// trimWriter Writer removing the spaces and tabs at the end of each line
+type trimWriter struct {
+	w       io.Writer
+	pending []byte
+}
+
+func (tw *trimWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	for _, b := range p {
+		switch b {
+		case ' ', '\t':
+			tw.pending = append(tw.pending, b)
+			continue
+		case '\r', '\n':
+			tw.pending = tw.pending[:0]
+		default:
+			buf.Write(tw.pending)
+			tw.pending = tw.pending[:0]
+		}
+		buf.WriteByte(b)
+	}
+	if _, err := tw.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// errWriter Writer keeping the first error of w and dropping the writes after it
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
+
+// isTerminal - check if w is a terminal, where the cursor can be moved
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -11,8 +11,6 @@ import (
 	"math"
 	"strings"
 	"unicode"
-
-	"github.com/mattn/go-runewidth"
 )
 
 const (
@@ -23,8 +21,23 @@ const (
 const defaultPenalty = 1e5
 
 // WrapString wraps s into a paragraph of lines of length lim, with minimal
-// raggedness.
+// raggedness. Words, URLs included, are never split: a word longer than lim
+// gets a line of its own and the returned limit grows to fit it.
+//
+// Lengths are display widths as given by DisplayWidth: ANSI escape sequences
+// do not count, wide East Asian characters count twice and ambiguous ones
+// follow the locale as detected by go-runewidth.
 func WrapString(s string, lim int) ([]string, int) {
+	return WrapStringWidth(s, lim, DisplayWidth)
+}
+
+// WrapStringWidth wraps s like WrapString, measuring the words with sw. Pass
+// the function given to Table.SetWidthFunc to wrap like a table does. A nil
+// sw uses DisplayWidth.
+func WrapStringWidth(s string, lim int, sw func(string) int) ([]string, int) {
+	if sw == nil {
+		sw = DisplayWidth
+	}
 	if s == sp {
 		return []string{sp}, lim
 	}
@@ -35,17 +48,108 @@ func WrapString(s string, lim int) ([]string, int) {
 	var lines []string
 	max := 0
 	for _, v := range words {
-		max = runewidth.StringWidth(v)
+		max = sw(v)
 		if max > lim {
 			lim = max
 		}
 	}
-	for _, line := range WrapWords(words, 1, lim, defaultPenalty) {
+	for _, line := range wrapWordsWidth(words, 1, lim, defaultPenalty, sw) {
 		lines = append(lines, strings.Join(line, sp))
 	}
 	return lines, lim
 }
 
+// WrapStringBalanced wraps s like WrapString, then narrows the limit as long
+// as the number of lines stays the same, so that all the lines, the last one
+// included, get about the same length.
+func WrapStringBalanced(s string, lim int) ([]string, int) {
+	return wrapBalancedWidth(s, lim, DisplayWidth)
+}
+
+// wrapBalancedWidth - WrapStringBalanced measuring the words with sw
+func wrapBalancedWidth(s string, lim int, sw func(string) int) ([]string, int) {
+	lines, lim := WrapStringWidth(s, lim, sw)
+	if len(lines) < 2 {
+		return lines, lim
+	}
+	lo := 0
+	for _, v := range splitWords(s) {
+		if w := sw(v); w > lo {
+			lo = w
+		}
+	}
+	hi := lim - 1
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		if try, _ := WrapStringWidth(s, mid, sw); len(try) <= len(lines) {
+			lines, lim = try, mid
+			hi = mid - 1
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lines, lim
+}
+
+// WrapStringBreakWords wraps s like WrapString, except that the words longer
+// than lim are broken across lines, so the returned limit only grows when a
+// single character is wider than lim. URLs are broken like the other words.
+func WrapStringBreakWords(s string, lim int) ([]string, int) {
+	return wrapBreakWordsWidth(s, lim, DisplayWidth, false)
+}
+
+// wrapBreakWordsWidth - WrapStringBreakWords measuring the words with sw
+// With keepURLs the http(s) URLs are kept whole, even when wider than lim.
+func wrapBreakWordsWidth(s string, lim int, sw func(string) int, keepURLs bool) ([]string, int) {
+	var words []string
+	for _, word := range splitWords(s) {
+		if keepURLs && isURL(word) {
+			words = append(words, word)
+			continue
+		}
+		words = append(words, breakWord(word, lim, sw)...)
+	}
+	if len(words) == 0 {
+		return WrapStringWidth(s, lim, sw)
+	}
+	return WrapStringWidth(strings.Join(words, sp), lim, sw)
+}
+
+// isURL - check if a word is an http or https URL
+// The escape sequences and the opening punctuation are not part of it.
+func isURL(word string) bool {
+	word = strings.TrimLeft(ansi.ReplaceAllLiteralString(word, ""), "(<[\"'")
+	word = strings.ToLower(word)
+	return strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://")
+}
+
+// breakWord - cut a word in pieces no wider than lim
+// A character wider than lim makes a piece of its own. Escape sequences are
+// never cut and take no width.
+func breakWord(word string, lim int, sw func(string) int) []string {
+	if sw(word) <= lim {
+		return []string{word}
+	}
+	escapes := ansi.FindAllStringIndex(word, -1)
+	var pieces []string
+	start, width := 0, 0
+	for i, r := range word {
+		if len(escapes) > 0 && i >= escapes[0][0] {
+			if i == escapes[0][1]-1 {
+				escapes = escapes[1:]
+			}
+			continue
+		}
+		w := sw(string(r))
+		if width+w > lim && i > start {
+			pieces = append(pieces, word[start:i])
+			start, width = i, 0
+		}
+		width += w
+	}
+	return append(pieces, word[start:])
+}
+
 func splitWords(s string) []string {
 	words := make([]string, 0, len(s)/5)
 	var wordBegin int
@@ -81,13 +185,18 @@ func splitWords(s string) []string {
 // happen when a single word is longer than lim units) have pen penalty units
 // added to the error.
 func WrapWords(words []string, spc, lim, pen int) [][]string {
+	return wrapWordsWidth(words, spc, lim, pen, DisplayWidth)
+}
+
+// wrapWordsWidth - WrapWords measuring the words with sw
+func wrapWordsWidth(words []string, spc, lim, pen int, sw func(string) int) [][]string {
 	n := len(words)
 	if n == 0 {
 		return nil
 	}
 	lengths := make([]int, n)
 	for i := 0; i < n; i++ {
-		lengths[i] = runewidth.StringWidth(words[i])
+		lengths[i] = sw(words[i])
 	}
 	nbrk := make([]int, n)
 	cost := make([]int, n)
@@ -133,3 +242,27 @@ func WrapWords(words []string, spc, lim, pen int) [][]string {
 func getLines(s string) []string {
 	return strings.Split(s, nl)
 }
+
+// getParagraphs joins the lines of each paragraph, paragraphs being separated
+// by blank lines.
+func getParagraphs(lines []string) []string {
+	var paras, para []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(para) > 0 {
+				paras = append(paras, strings.Join(para, sp))
+				para = nil
+			}
+			continue
+		}
+		para = append(para, line)
+	}
+	if len(para) > 0 {
+		paras = append(paras, strings.Join(para, sp))
+	}
+	if len(paras) == 0 {
+		// Only blank lines, keep them as a single paragraph
+		return []string{strings.Join(lines, sp)}
+	}
+	return paras
+}
@@ -143,3 +143,113 @@ func TestSplitWords(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapKeepsURLs(t *testing.T) {
+	url := "https://example.com/some/very/long/path?with=query&and=more"
+	got, lim := WrapString("see "+url+" for details", 10)
+	checkEqual(t, got, []string{"see", url, "for details"})
+	checkEqual(t, lim, len(url))
+
+	var buf strings.Builder
+	table := NewWriter(&buf)
+	table.SetColWidth(10)
+	table.Append([]string{"docs at " + url})
+	table.Render()
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "https") && !strings.Contains(line, url) {
+			t.Fatalf("url was broken across lines:\n%s", buf.String())
+		}
+	}
+
+	// Breaking the long words of a column keeps the URLs unless told otherwise
+	for _, keep := range []bool{true, false} {
+		buf.Reset()
+		table = NewWriter(&buf)
+		table.SetColMaxWidth(0, 20)
+		table.SetKeepURLsIntact(keep)
+		table.Append([]string{url})
+		table.Render()
+		checkEqual(t, strings.Contains(buf.String(), url), keep, ConditionString(keep, "URLs kept", "URLs broken"))
+	}
+}
+
+func TestWrapStringBalanced(t *testing.T) {
+	got, lim := WrapStringBalanced(text, 20)
+	checkEqual(t, got, []string{"The quick brown", "fox jumps over", "the lazy dog."})
+	checkEqual(t, lim, 15)
+
+	got, _ = WrapStringBalanced("aaaa bbbb cccc dddd e", 20)
+	checkEqual(t, got, []string{"aaaa bbbb", "cccc dddd e"})
+
+	got, _ = WrapStringBalanced(text, 100)
+	checkEqual(t, got, []string{text})
+}
+
+func TestWrapModeBalanced(t *testing.T) {
+	var buf strings.Builder
+	table := NewWriter(&buf)
+	table.SetColWidth(20)
+	table.SetWrapMode(WRAP_BALANCED)
+	table.Append([]string{text})
+	table.Render()
+
+	want := `+-----------------+
+| The quick brown |
+| fox jumps over  |
+| the lazy dog.   |
++-----------------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestSetWrapFunc(t *testing.T) {
+	var buf strings.Builder
+	table := NewWriter(&buf)
+	table.SetColWidth(4)
+	table.SetWrapFunc(func(s string, lim int) []string {
+		var lines []string
+		for len(s) > lim {
+			lines = append(lines, s[:lim-1]+"-")
+			s = s[lim-1:]
+		}
+		return append(lines, s)
+	})
+	table.Append([]string{"abcdefgh"})
+	table.Render()
+
+	want := `+------+
+| abc- |
+| def- |
+| gh   |
++------+
+`
+	checkEqual(t, buf.String(), want)
+}
+
+func TestWrapStringBreakWords(t *testing.T) {
+	got, lim := WrapStringBreakWords("see https://example.com/a/long/path now", 10)
+	checkEqual(t, got, []string{"see", "https://ex", "ample.com/", "a/long/pat", "h now"})
+	checkEqual(t, lim, 10)
+
+	// WrapString keeps the long word whole and widens the limit
+	_, lim = WrapString("see https://example.com/a/long/path now", 10)
+	checkEqual(t, lim, 31)
+}
+
+func TestWrapStringWidth(t *testing.T) {
+	red := "\033[31mred\033[0m"
+	got, lim := WrapStringWidth(red+" green blue", 9, DisplayWidth)
+	checkEqual(t, got, []string{red + " green", "blue"})
+	checkEqual(t, lim, 9)
+}
+
+func TestWrapStringColored(t *testing.T) {
+	red := func(s string) string { return "\033[31m" + s + "\033[0m" }
+	got, lim := WrapString(red("quick")+" "+red("brown")+" fox", 11)
+	checkEqual(t, got, []string{red("quick") + " " + red("brown"), "fox"})
+	checkEqual(t, lim, 11)
+
+	// Long words are broken between the escape sequences
+	got, _ = WrapStringBreakWords(red("abcdefgh"), 4)
+	checkEqual(t, got, []string{"\033[31mabcd", "efgh\033[0m"})
+}